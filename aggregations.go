@@ -1,5 +1,12 @@
 package elastic
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudresty/go-elastic/query"
+)
+
 // AggregationBuilder provides a fluent interface for building aggregations
 type AggregationBuilder struct {
 	agg map[string]any
@@ -16,18 +23,43 @@ func NewTermsAggregation(field string) *AggregationBuilder {
 	}
 }
 
-// NewDateHistogramAggregation creates a date histogram aggregation
+// NewDateHistogramAggregation creates a date histogram aggregation. The interval is sent as
+// calendar_interval for calendar units ("day", "1M", "quarter", ...) and as fixed_interval
+// otherwise ("30m", "90s"); the deprecated interval parameter is never emitted. Pass an empty
+// interval and call CalendarInterval or FixedInterval to choose explicitly.
 func NewDateHistogramAggregation(field string, interval string) *AggregationBuilder {
+	dateHist := map[string]any{
+		"field": field,
+	}
+	if interval != "" {
+		dateHist[dateHistogramIntervalKey(interval)] = interval
+	}
 	return &AggregationBuilder{
 		agg: map[string]any{
-			"date_histogram": map[string]any{
-				"field":    field,
-				"interval": interval,
-			},
+			"date_histogram": dateHist,
 		},
 	}
 }
 
+// calendarIntervals lists the intervals accepted by calendar_interval
+var calendarIntervals = map[string]bool{
+	"minute": true, "1m": true,
+	"hour": true, "1h": true,
+	"day": true, "1d": true,
+	"week": true, "1w": true,
+	"month": true, "1M": true,
+	"quarter": true, "1q": true,
+	"year": true, "1y": true,
+}
+
+// dateHistogramIntervalKey returns calendar_interval for calendar units and fixed_interval otherwise
+func dateHistogramIntervalKey(interval string) string {
+	if calendarIntervals[interval] {
+		return "calendar_interval"
+	}
+	return "fixed_interval"
+}
+
 // NewRangeAggregation creates a range aggregation
 func NewRangeAggregation(field string) *AggregationBuilder {
 	return &AggregationBuilder{
@@ -107,6 +139,93 @@ func NewStatsAggregation(field string) *AggregationBuilder {
 	}
 }
 
+// NewCardinalityAggregation creates a cardinality (approximate distinct count) aggregation
+func NewCardinalityAggregation(field string) *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"cardinality": map[string]any{
+				"field": field,
+			},
+		},
+	}
+}
+
+// NewWeightedAvgAggregation creates a weighted average aggregation; set the fields with Value and Weight
+func NewWeightedAvgAggregation() *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"weighted_avg": map[string]any{},
+		},
+	}
+}
+
+// NewMedianAbsoluteDeviationAggregation creates a median absolute deviation aggregation
+func NewMedianAbsoluteDeviationAggregation(field string) *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"median_absolute_deviation": map[string]any{
+				"field": field,
+			},
+		},
+	}
+}
+
+// NewMatrixStatsAggregation creates a matrix_stats aggregation, which computes per-field statistics
+// and the covariance and correlation between every pair of the given numeric fields
+func NewMatrixStatsAggregation(fields ...string) *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"matrix_stats": map[string]any{
+				"fields": fields,
+			},
+		},
+	}
+}
+
+// NewBoxplotAggregation creates a boxplot aggregation (min, max, median and quartiles of a field)
+func NewBoxplotAggregation(field string) *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"boxplot": map[string]any{
+				"field": field,
+			},
+		},
+	}
+}
+
+// NewAdjacencyMatrixAggregation creates an adjacency_matrix aggregation, which returns a bucket per
+// filter and per pair of overlapping filters (e.g. users in both segment A and segment B);
+// add the filters with AddFilter
+func NewAdjacencyMatrixAggregation() *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"adjacency_matrix": map[string]any{
+				"filters": map[string]any{},
+			},
+		},
+	}
+}
+
+// NewSamplerAggregation creates a sampler aggregation, which limits its sub-aggregations to the
+// top-scoring documents of each shard; set the sample size with ShardSize
+func NewSamplerAggregation() *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"sampler": map[string]any{},
+		},
+	}
+}
+
+// NewDiversifiedSamplerAggregation creates a diversified_sampler aggregation, a sampler that caps
+// the documents sharing a value of Field (see MaxDocsPerValue) so the sample is not dominated by one source
+func NewDiversifiedSamplerAggregation() *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			"diversified_sampler": map[string]any{},
+		},
+	}
+}
+
 // Size sets the size for terms aggregations
 func (a *AggregationBuilder) Size(size int) *AggregationBuilder {
 	if terms, ok := a.agg["terms"].(map[string]any); ok {
@@ -133,6 +252,40 @@ func (a *AggregationBuilder) MinDocCount(count int) *AggregationBuilder {
 	return a
 }
 
+// ShowTermDocCountError reports the worst-case count error of each bucket of terms aggregations
+// (TermsBucket.DocCountErrorUpperBound)
+func (a *AggregationBuilder) ShowTermDocCountError() *AggregationBuilder {
+	if terms, ok := a.agg["terms"].(map[string]any); ok {
+		terms["show_term_doc_count_error"] = true
+	}
+	return a
+}
+
+// PrecisionThreshold sets the count below which cardinality aggregations are expected to be
+// close to exact (max 40000); higher values trade memory for accuracy
+func (a *AggregationBuilder) PrecisionThreshold(threshold int) *AggregationBuilder {
+	if cardinality, ok := a.agg["cardinality"].(map[string]any); ok {
+		cardinality["precision_threshold"] = threshold
+	}
+	return a
+}
+
+// Value sets the field providing the values for weighted average aggregations
+func (a *AggregationBuilder) Value(field string) *AggregationBuilder {
+	if weightedAvg, ok := a.agg["weighted_avg"].(map[string]any); ok {
+		weightedAvg["value"] = map[string]any{"field": field}
+	}
+	return a
+}
+
+// Weight sets the field providing the weights for weighted average aggregations
+func (a *AggregationBuilder) Weight(field string) *AggregationBuilder {
+	if weightedAvg, ok := a.agg["weighted_avg"].(map[string]any); ok {
+		weightedAvg["weight"] = map[string]any{"field": field}
+	}
+	return a
+}
+
 // AddRange adds a range to a range aggregation
 func (a *AggregationBuilder) AddRange(key string, from, to *float64) *AggregationBuilder {
 	if rangeAgg, ok := a.agg["range"].(map[string]any); ok {
@@ -150,6 +303,44 @@ func (a *AggregationBuilder) AddRange(key string, from, to *float64) *Aggregatio
 	return a
 }
 
+// ShardSize sets the number of terms each shard returns for terms aggregations (raise it when
+// TermsAggResult.Approximate reports inaccurate counts), or the number of top-scoring documents
+// sampled per shard by sampler and diversified_sampler aggregations
+func (a *AggregationBuilder) ShardSize(size int) *AggregationBuilder {
+	for _, aggType := range []string{"terms", "sampler", "diversified_sampler"} {
+		if sampler, ok := a.agg[aggType].(map[string]any); ok {
+			sampler["shard_size"] = size
+		}
+	}
+	return a
+}
+
+// Field sets the field whose values are de-duplicated by diversified_sampler aggregations
+func (a *AggregationBuilder) Field(field string) *AggregationBuilder {
+	if sampler, ok := a.agg["diversified_sampler"].(map[string]any); ok {
+		sampler["field"] = field
+	}
+	return a
+}
+
+// MaxDocsPerValue sets how many sampled documents may share a field value in diversified_sampler aggregations
+func (a *AggregationBuilder) MaxDocsPerValue(count int) *AggregationBuilder {
+	if sampler, ok := a.agg["diversified_sampler"].(map[string]any); ok {
+		sampler["max_docs_per_value"] = count
+	}
+	return a
+}
+
+// AddFilter adds a named filter to an adjacency_matrix aggregation
+func (a *AggregationBuilder) AddFilter(name string, filter *query.Builder) *AggregationBuilder {
+	if adjacencyMatrix, ok := a.agg["adjacency_matrix"].(map[string]any); ok {
+		if filters, ok := adjacencyMatrix["filters"].(map[string]any); ok {
+			filters[name] = filter.Build()
+		}
+	}
+	return a
+}
+
 // Format sets the format for date histogram aggregations
 func (a *AggregationBuilder) Format(format string) *AggregationBuilder {
 	if dateHist, ok := a.agg["date_histogram"].(map[string]any); ok {
@@ -166,6 +357,27 @@ func (a *AggregationBuilder) TimeZone(tz string) *AggregationBuilder {
 	return a
 }
 
+// CalendarInterval sets a calendar-aware interval ("day", "1w", "month", ...) for date histogram aggregations
+func (a *AggregationBuilder) CalendarInterval(interval string) *AggregationBuilder {
+	return a.setDateHistogramInterval("calendar_interval", interval)
+}
+
+// FixedInterval sets a fixed-length interval ("30s", "12h", "7d", ...) for date histogram aggregations
+func (a *AggregationBuilder) FixedInterval(interval string) *AggregationBuilder {
+	return a.setDateHistogramInterval("fixed_interval", interval)
+}
+
+// setDateHistogramInterval sets one interval key and removes the others, since Elasticsearch accepts only one
+func (a *AggregationBuilder) setDateHistogramInterval(key, interval string) *AggregationBuilder {
+	if dateHist, ok := a.agg["date_histogram"].(map[string]any); ok {
+		delete(dateHist, "interval")
+		delete(dateHist, "calendar_interval")
+		delete(dateHist, "fixed_interval")
+		dateHist[key] = interval
+	}
+	return a
+}
+
 // SubAggregation adds a sub-aggregation
 func (a *AggregationBuilder) SubAggregation(name string, subAgg *AggregationBuilder) *AggregationBuilder {
 	if a.agg["aggs"] == nil {
@@ -177,6 +389,105 @@ func (a *AggregationBuilder) SubAggregation(name string, subAgg *AggregationBuil
 	return a
 }
 
+// OrderBySubAgg orders the buckets of a terms or histogram aggregation by a sub-aggregation
+// metric, e.g. OrderBySubAgg("avg_price", "desc") or OrderBySubAgg("price_stats.max", "asc").
+// Call Validate to check that the referenced sub-aggregation exists.
+func (a *AggregationBuilder) OrderBySubAgg(path string, direction string) *AggregationBuilder {
+	for _, aggType := range []string{"terms", "histogram", "date_histogram"} {
+		if bucketAgg, ok := a.agg[aggType].(map[string]any); ok {
+			bucketAgg["order"] = map[string]any{
+				path: direction,
+			}
+		}
+	}
+	return a
+}
+
+// NewPipelineAggregation creates a pipeline aggregation (e.g. "avg_bucket", "derivative",
+// "cumulative_sum") reading its input from bucketsPath; see BucketsPath
+func NewPipelineAggregation(pipelineType string, bucketsPath string) *AggregationBuilder {
+	return &AggregationBuilder{
+		agg: map[string]any{
+			pipelineType: map[string]any{
+				"buckets_path": bucketsPath,
+			},
+		},
+	}
+}
+
+// BucketsPath builds a buckets_path by joining aggregation names with ">",
+// e.g. BucketsPath("sales_per_month", "sales") returns "sales_per_month>sales".
+// A metric of a multi-value aggregation is addressed with ".", e.g. "price_stats.avg".
+func BucketsPath(aggregations ...string) string {
+	return strings.Join(aggregations, ">")
+}
+
+// Validate checks that sub-aggregation orders and sibling pipeline buckets paths
+// reference aggregations that exist, recursing into sub-aggregations
+func (a *AggregationBuilder) Validate() error {
+	return validateAggregation(a.agg)
+}
+
+// validateAggregation validates a single aggregation map and its sub-aggregations
+func validateAggregation(agg map[string]any) error {
+	subAggs, _ := agg["aggs"].(map[string]any)
+
+	// Orders on bucket aggregations must reference a sub-aggregation (or _count/_key)
+	for _, aggType := range []string{"terms", "histogram", "date_histogram"} {
+		bucketAgg, ok := agg[aggType].(map[string]any)
+		if !ok {
+			continue
+		}
+		order, _ := bucketAgg["order"].(map[string]any)
+		for path := range order {
+			if !referencesKnownAggregation(path, subAggs) {
+				return fmt.Errorf("order path '%s' references an unknown sub-aggregation", path)
+			}
+		}
+	}
+
+	for name, sub := range subAggs {
+		subAgg, ok := sub.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		// Sibling pipeline aggregations must point at another aggregation on the same level
+		for aggType, body := range subAgg {
+			params, ok := body.(map[string]any)
+			if !ok || aggType == "aggs" {
+				continue
+			}
+			if bucketsPath, ok := params["buckets_path"].(string); ok && !referencesKnownAggregation(bucketsPath, subAggs) {
+				return fmt.Errorf("buckets_path '%s' of aggregation '%s' references an unknown aggregation", bucketsPath, name)
+			}
+		}
+
+		if err := validateAggregation(subAgg); err != nil {
+			return fmt.Errorf("sub-aggregation '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// referencesKnownAggregation reports whether the first element of an order or buckets path
+// names one of the given aggregations or a built-in key
+func referencesKnownAggregation(path string, aggs map[string]any) bool {
+	name := path
+	if i := strings.IndexAny(name, ">.["); i >= 0 {
+		name = name[:i]
+	}
+
+	switch name {
+	case "_count", "_key", "_term":
+		return true
+	}
+
+	_, ok := aggs[name]
+	return ok
+}
+
 // Build returns the aggregation as a map
 func (a *AggregationBuilder) Build() map[string]any {
 	return a.agg
@@ -0,0 +1,187 @@
+package elastic
+
+import (
+	"testing"
+
+	"github.com/cloudresty/go-elastic/query"
+)
+
+func TestOrderBySubAgg(t *testing.T) {
+	agg := NewTermsAggregation("category").
+		SubAggregation("avg_price", NewAvgAggregation("price")).
+		OrderBySubAgg("avg_price", "desc")
+
+	order := agg.Build()["terms"].(map[string]any)["order"].(map[string]any)
+	if order["avg_price"] != "desc" {
+		t.Errorf("Expected order avg_price=desc, got %v", order)
+	}
+	if err := agg.Validate(); err != nil {
+		t.Errorf("Expected valid aggregation, got %v", err)
+	}
+
+	invalid := NewTermsAggregation("category").
+		SubAggregation("avg_price", NewAvgAggregation("price")).
+		OrderBySubAgg("avg_cost", "desc")
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected error for order on unknown sub-aggregation")
+	}
+
+	if err := NewTermsAggregation("category").OrderBySubAgg("_count", "asc").Validate(); err != nil {
+		t.Errorf("Expected _count order to be valid, got %v", err)
+	}
+}
+
+func TestPipelineBucketsPath(t *testing.T) {
+	if got := BucketsPath("sales_per_month", "sales"); got != "sales_per_month>sales" {
+		t.Errorf("Expected 'sales_per_month>sales', got '%s'", got)
+	}
+
+	monthly := NewDateHistogramAggregation("date", "month").
+		SubAggregation("sales", NewSumAggregation("price"))
+
+	// Sibling pipeline aggregations live next to the aggregation they read from
+	root := NewTermsAggregation("region").
+		SubAggregation("sales_per_month", monthly).
+		SubAggregation("max_monthly_sales", NewPipelineAggregation("max_bucket", BucketsPath("sales_per_month", "sales")))
+	if err := root.Validate(); err != nil {
+		t.Errorf("Expected valid pipeline aggregation, got %v", err)
+	}
+
+	broken := NewTermsAggregation("region").
+		SubAggregation("max_monthly_sales", NewPipelineAggregation("max_bucket", BucketsPath("sales_by_month", "sales")))
+	if err := broken.Validate(); err == nil {
+		t.Error("Expected error for buckets_path referencing an unknown aggregation")
+	}
+}
+
+func TestWeightedAvgAndMADAggregations(t *testing.T) {
+	weighted := NewWeightedAvgAggregation().Value("rating").Weight("votes").Build()["weighted_avg"].(map[string]any)
+	if weighted["value"].(map[string]any)["field"] != "rating" {
+		t.Errorf("Expected value field rating, got %v", weighted["value"])
+	}
+	if weighted["weight"].(map[string]any)["field"] != "votes" {
+		t.Errorf("Expected weight field votes, got %v", weighted["weight"])
+	}
+
+	mad := NewMedianAbsoluteDeviationAggregation("latency").Build()
+	if mad["median_absolute_deviation"].(map[string]any)["field"] != "latency" {
+		t.Errorf("Expected MAD on latency, got %v", mad)
+	}
+
+	// Value/Weight only apply to weighted_avg
+	avg := NewAvgAggregation("price").Value("other").Build()["avg"].(map[string]any)
+	if _, ok := avg["value"]; ok {
+		t.Error("Expected Value to be ignored on a non weighted_avg aggregation")
+	}
+}
+
+func TestCardinalityPrecisionThreshold(t *testing.T) {
+	cardinality := NewCardinalityAggregation("user_id").PrecisionThreshold(3000).Build()["cardinality"].(map[string]any)
+	if cardinality["precision_threshold"] != 3000 {
+		t.Errorf("Expected precision_threshold 3000, got %v", cardinality["precision_threshold"])
+	}
+
+	helper := CardinalityAggregation("user_id", 500)["cardinality"].(map[string]any)
+	if helper["precision_threshold"] != 500 {
+		t.Errorf("Expected helper precision_threshold 500, got %v", helper["precision_threshold"])
+	}
+	if _, ok := CardinalityAggregation("user_id")["cardinality"].(map[string]any)["precision_threshold"]; ok {
+		t.Error("Expected no precision_threshold by default")
+	}
+}
+
+func TestDateHistogramIntervals(t *testing.T) {
+	tests := map[string]string{
+		"month": "calendar_interval",
+		"1d":    "calendar_interval",
+		"30m":   "fixed_interval",
+		"7d":    "fixed_interval",
+	}
+	for interval, key := range tests {
+		dateHist := NewDateHistogramAggregation("ts", interval).Build()["date_histogram"].(map[string]any)
+		if dateHist[key] != interval {
+			t.Errorf("Expected %s=%s, got %v", key, interval, dateHist)
+		}
+		if _, ok := dateHist["interval"]; ok {
+			t.Errorf("Expected no deprecated interval key for %s", interval)
+		}
+	}
+
+	explicit := NewDateHistogramAggregation("ts", "1d").FixedInterval("24h").Build()["date_histogram"].(map[string]any)
+	if explicit["fixed_interval"] != "24h" {
+		t.Errorf("Expected fixed_interval=24h, got %v", explicit)
+	}
+	if _, ok := explicit["calendar_interval"]; ok {
+		t.Error("Expected FixedInterval to replace calendar_interval")
+	}
+
+	helper := DateHistogramAggregation("ts", "week")["date_histogram"].(map[string]any)
+	if helper["calendar_interval"] != "week" {
+		t.Errorf("Expected helper calendar_interval=week, got %v", helper)
+	}
+}
+
+func TestAdjacencyMatrixAggregation(t *testing.T) {
+	agg := NewAdjacencyMatrixAggregation().
+		AddFilter("segment_a", query.Term("segment", "a")).
+		AddFilter("segment_b", query.Term("segment", "b")).
+		Build()
+
+	filters := agg["adjacency_matrix"].(map[string]any)["filters"].(map[string]any)
+	if len(filters) != 2 {
+		t.Fatalf("Expected 2 filters, got %v", filters)
+	}
+	term := filters["segment_a"].(map[string]any)["term"].(map[string]any)
+	if term["segment"] != "a" {
+		t.Errorf("Expected segment_a to filter on segment a, got %v", filters["segment_a"])
+	}
+
+	// AddFilter only applies to adjacency_matrix
+	terms := NewTermsAggregation("segment").AddFilter("segment_a", query.Term("segment", "a")).Build()["terms"].(map[string]any)
+	if _, ok := terms["filters"]; ok {
+		t.Error("Expected AddFilter to be ignored on a non adjacency_matrix aggregation")
+	}
+}
+
+func TestSamplerAggregations(t *testing.T) {
+	sampler := NewSamplerAggregation().
+		ShardSize(200).
+		SubAggregation("keywords", NewTermsAggregation("tags")).
+		Build()
+	if sampler["sampler"].(map[string]any)["shard_size"] != 200 {
+		t.Errorf("Expected sampler shard_size 200, got %v", sampler["sampler"])
+	}
+	if _, ok := sampler["aggs"].(map[string]any)["keywords"]; !ok {
+		t.Errorf("Expected keywords sub-aggregation, got %v", sampler["aggs"])
+	}
+
+	diversified := NewDiversifiedSamplerAggregation().Field("author").ShardSize(100).MaxDocsPerValue(3).Build()["diversified_sampler"].(map[string]any)
+	if diversified["field"] != "author" || diversified["shard_size"] != 100 || diversified["max_docs_per_value"] != 3 {
+		t.Errorf("Unexpected diversified_sampler: %v", diversified)
+	}
+
+	// Field and MaxDocsPerValue only apply to diversified_sampler
+	plain := NewSamplerAggregation().Field("author").MaxDocsPerValue(3).Build()["sampler"].(map[string]any)
+	if len(plain) != 0 {
+		t.Errorf("Expected diversified_sampler settings to be ignored on sampler, got %v", plain)
+	}
+}
+
+func TestMatrixStatsAndBoxplotAggregations(t *testing.T) {
+	matrix := NewMatrixStatsAggregation("income", "poverty").Build()["matrix_stats"].(map[string]any)
+	if fields := matrix["fields"].([]string); len(fields) != 2 || fields[0] != "income" || fields[1] != "poverty" {
+		t.Errorf("Expected fields [income poverty], got %v", matrix["fields"])
+	}
+
+	boxplot := NewBoxplotAggregation("load_time").Build()["boxplot"].(map[string]any)
+	if boxplot["field"] != "load_time" {
+		t.Errorf("Expected boxplot on load_time, got %v", boxplot)
+	}
+}
+
+func TestTermsShardSizeAndDocCountError(t *testing.T) {
+	terms := NewTermsAggregation("tags").Size(10).ShardSize(50).ShowTermDocCountError().Build()["terms"].(map[string]any)
+	if terms["shard_size"] != 50 || terms["show_term_doc_count_error"] != true {
+		t.Errorf("Expected shard_size 50 and show_term_doc_count_error, got %v", terms)
+	}
+}
@@ -3,6 +3,7 @@ package elastic
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -37,6 +38,9 @@ type Client struct {
 	reconnectCount int64
 	lastReconnect  time.Time
 	healthTicker   *time.Ticker
+	healthState    healthCheckState
+	serverInfo     *ServerInfo
+	poolStats      *connPoolStats
 	shutdownChan   chan struct{}
 	shutdownOnce   sync.Once
 }
@@ -59,18 +63,28 @@ type Config struct {
 
 	// Performance settings
 	CompressionEnabled   bool  `env:"ELASTICSEARCH_COMPRESSION_ENABLED,default=true"`
+	DisableRetry         bool  `env:"ELASTICSEARCH_DISABLE_RETRY,default=false"` // Set to true to handle retries yourself
 	RetryOnStatus        []int `env:"ELASTICSEARCH_RETRY_ON_STATUS"`
 	MaxRetries           int   `env:"ELASTICSEARCH_MAX_RETRIES,default=3"`
 	DiscoverNodesOnStart bool  `env:"ELASTICSEARCH_DISCOVER_NODES_ON_START,default=false"`
 
+	// RequestCompressionThreshold gzips request bodies of at least this many bytes (0 = disabled)
+	RequestCompressionThreshold int `env:"ELASTICSEARCH_REQUEST_COMPRESSION_THRESHOLD,default=0"`
+
+	// DiscoverNodesInterval periodically re-sniffs cluster nodes (0 = disabled)
+	DiscoverNodesInterval time.Duration `env:"ELASTICSEARCH_DISCOVER_NODES_INTERVAL,default=0s"`
+
 	// Connection pool settings
 	MaxIdleConns        int           `env:"ELASTICSEARCH_MAX_IDLE_CONNS,default=100"`
 	MaxIdleConnsPerHost int           `env:"ELASTICSEARCH_MAX_IDLE_CONNS_PER_HOST,default=10"`
 	IdleConnTimeout     time.Duration `env:"ELASTICSEARCH_IDLE_CONN_TIMEOUT,default=90s"`
 	MaxConnLifetime     time.Duration `env:"ELASTICSEARCH_MAX_CONN_LIFETIME,default=0s"` // 0 = no limit
 
+	// Startup settings
+	LazyConnect bool `env:"ELASTICSEARCH_LAZY_CONNECT,default=false"` // Skip the connectivity check in NewClient and connect in the background
+
 	// Timeout settings
-	ConnectTimeout time.Duration `env:"ELASTICSEARCH_CONNECT_TIMEOUT,default=10s"`
+	ConnectTimeout time.Duration `env:"ELASTICSEARCH_CONNECT_TIMEOUT,default=10s"` // Dial and TLS handshake timeout
 	RequestTimeout time.Duration `env:"ELASTICSEARCH_REQUEST_TIMEOUT,default=30s"`
 
 	// Reconnection settings
@@ -84,6 +98,9 @@ type Config struct {
 	HealthCheckEnabled  bool          `env:"ELASTICSEARCH_HEALTH_CHECK_ENABLED,default=true"`
 	HealthCheckInterval time.Duration `env:"ELASTICSEARCH_HEALTH_CHECK_INTERVAL,default=30s"`
 
+	// HealthCheckCallback is called with the outcome of every background health check (not configurable via environment)
+	HealthCheckCallback func(healthy bool, err error)
+
 	// Application settings
 	AppName        string `env:"ELASTICSEARCH_APP_NAME,default=go-elastic-app"`
 	ConnectionName string `env:"ELASTICSEARCH_CONNECTION_NAME"`
@@ -91,8 +108,32 @@ type Config struct {
 	// ID Generation settings
 	IDMode IDMode `env:"ELASTICSEARCH_ID_MODE,default=elastic"`
 
+	// Document settings
+	SkipUpdateTimestamp bool `env:"ELASTICSEARCH_SKIP_UPDATE_TIMESTAMP,default=false"` // Don't inject updated_at into partial updates
+	ReadOnly            bool `env:"ELASTICSEARCH_READ_ONLY,default=false"`             // Reject document and index writes with ErrReadOnly
+
+	// Search settings
+	DefaultScrollSize int `env:"ELASTICSEARCH_DEFAULT_SCROLL_SIZE,default=1000"` // Batch size for scroll searches without WithSize
+	MaxResultWindow   int `env:"ELASTICSEARCH_MAX_RESULT_WINDOW,default=0"`      // Reject searches whose from + size exceeds this client-side (0 = disabled)
+
+	SearchTimeout time.Duration `env:"ELASTICSEARCH_SEARCH_TIMEOUT,default=0s"` // Server-side timeout for searches without WithTimeout (0 = none)
+
+	// Retry policy hooks (not configurable via environment)
+	RetryBackoff func(attempt int) time.Duration // Delay before each retry attempt; nil retries immediately
+	RetryOnError func(err error) bool            // Decides whether a transport-level error is retried; nil uses the client default
+
+	// DocumentValidator checks documents before they are indexed (not configurable via environment)
+	DocumentValidator func(document any) error
+
 	// Logger for internal logging (not configurable via environment)
 	Logger Logger
+
+	// Codec for document, bulk and search (de)serialization (not configurable via environment)
+	Codec Codec
+
+	// Transport replaces the HTTP transport built from the connection pool settings, e.g. with a
+	// canned-response transport from the elastictest package in unit tests (not configurable via environment)
+	Transport http.RoundTripper
 }
 
 // BuildConnectionAddresses constructs Elasticsearch connection addresses from configuration
@@ -129,9 +170,34 @@ func (c *Config) BuildConnectionAddresses() []string {
 
 // ConnectionStats represents connection statistics
 type ConnectionStats struct {
-	IsConnected   bool      `json:"is_connected"`
-	Reconnects    int64     `json:"reconnects"`
-	LastReconnect time.Time `json:"last_reconnect"`
+	IsConnected          bool      `json:"is_connected"`
+	Reconnects           int64     `json:"reconnects"`
+	LastReconnect        time.Time `json:"last_reconnect"`
+	LastHealthCheckError error     `json:"-"`
+	LastHealthCheckTime  time.Time `json:"last_health_check_time"`
+
+	// Connection pool of the HTTP transport
+	OpenConnections  int64 `json:"open_connections"`   // Connections currently established
+	InUseConnections int64 `json:"in_use_connections"` // Connections serving a request (requests in flight with HTTP/2)
+	IdleConnections  int64 `json:"idle_connections"`   // Open connections waiting in the idle pool
+}
+
+// HealthStatus represents a detailed snapshot of the client's health
+type HealthStatus struct {
+	Healthy             bool      `json:"healthy"`
+	IsConnected         bool      `json:"is_connected"`
+	LastCheckTime       time.Time `json:"last_check_time"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Reconnects          int64     `json:"reconnects"`
+	LastReconnect       time.Time `json:"last_reconnect"`
+}
+
+// healthCheckState holds the outcome of the most recent health check
+type healthCheckState struct {
+	lastError           error
+	lastCheck           time.Time
+	consecutiveFailures int
 }
 
 // ClientOption represents a functional option for configuring the client
@@ -253,6 +319,216 @@ func WithConnectionName(name string) ClientOption {
 	}
 }
 
+// WithRetry enables or disables automatic request retries (overrides environment)
+// When disabled, no request is retried by the transport, regardless of RetryOnStatus.
+func WithRetry(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.DisableRetry = !enabled
+	}
+}
+
+// WithRequestCompression gzips request bodies of at least threshold bytes, such as large bulk
+// uploads and queries (overrides environment). A threshold of 0 disables request compression.
+func WithRequestCompression(threshold int) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.RequestCompressionThreshold = threshold
+	}
+}
+
+// WithSearchTimeout sets the default server-side timeout applied to searches that don't use WithTimeout
+func WithSearchTimeout(timeout time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.SearchTimeout = timeout
+	}
+}
+
+// WithReadOnly makes the client reject writes (overrides environment). Document writes (index, create,
+// update, delete, bulk, by-query), index creation and deletion, reindex, force merge and changes to
+// mappings, settings, aliases, templates and stored scripts return ErrReadOnly before any request is
+// sent. Searches, counts and cluster reads are unaffected; DoRequest only sends reads.
+func WithReadOnly(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.ReadOnly = enabled
+	}
+}
+
+// WithUpdateTimestamp controls whether partial updates inject an updated_at timestamp (enabled by default)
+func WithUpdateTimestamp(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.SkipUpdateTimestamp = !enabled
+	}
+}
+
+// WithRetryBackoff sets the delay applied before each retry attempt
+// Example: elastic.WithRetryBackoff(func(attempt int) time.Duration { return time.Duration(attempt) * 100 * time.Millisecond })
+func WithRetryBackoff(backoff func(attempt int) time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.RetryBackoff = backoff
+	}
+}
+
+// WithRetryOnError sets a function that decides whether a transport-level error
+// (e.g. connection reset) should be retried
+func WithRetryOnError(retryOnError func(err error) bool) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.RetryOnError = retryOnError
+	}
+}
+
+// WithLazyConnect skips the connectivity check in NewClient (overrides environment), so a client
+// can be created before Elasticsearch is reachable. The client starts disconnected and connects
+// through the health check and reconnect loop; requests made before then may fail.
+func WithLazyConnect(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.LazyConnect = enabled
+	}
+}
+
+// WithDocumentValidator sets a function that checks every document before it is indexed
+// (single-document index/create, bulk index/create and IndexMany). A validation error skips
+// the write and is returned wrapped in ErrInvalidDocument. Partial updates are not validated.
+func WithDocumentValidator(validator func(document any) error) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.DocumentValidator = validator
+	}
+}
+
+// WithNodeDiscovery configures node sniffing (overrides environment)
+// When onStart is true the cluster nodes are discovered when the client is created.
+// A positive interval re-discovers nodes periodically so nodes added or removed later are picked up.
+func WithNodeDiscovery(onStart bool, interval time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.DiscoverNodesOnStart = onStart
+		opts.config.DiscoverNodesInterval = interval
+	}
+}
+
+// WithHealthCheckCallback sets a function called with the outcome of every background health
+// check, so applications can react to connectivity changes, e.g. flip a readiness probe or emit
+// an alert. It runs on the health check goroutine before any reconnect attempt, so keep it fast.
+func WithHealthCheckCallback(callback func(healthy bool, err error)) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.HealthCheckCallback = callback
+	}
+}
+
+// WithTransport sets the HTTP transport used to reach Elasticsearch instead of the one built from
+// the connection pool settings (MaxIdleConns, ConnectTimeout, MaxConnLifetime, ...). Request
+// compression still applies. Use it with elastictest.NewTransport to unit test code without a cluster.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.Transport = transport
+	}
+}
+
 // WithLogger sets a custom logger for internal logging operations.
 // If not provided, a NopLogger (silent) will be used by default.
 // Example: client, err := elastic.NewClient(elastic.WithLogger(myLogger))
@@ -265,6 +541,24 @@ func WithLogger(logger Logger) ClientOption {
 	}
 }
 
+// WithCodec sets the JSON codec used to encode documents and bulk bodies and to decode search
+// responses. If not provided, JSONCodec (encoding/json) will be used by default.
+// Example: client, err := elastic.NewClient(elastic.WithCodec(sonicCodec{}))
+func WithCodec(codec Codec) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.config == nil {
+			// Create a new config if none exists
+			config, err := loadConfigWithPrefix("")
+			if err != nil {
+				// Use default config if loading fails
+				config = &Config{}
+			}
+			opts.config = config
+		}
+		opts.config.Codec = codec
+	}
+}
+
 // FromEnv loads configuration from environment variables using the default
 // "ELASTICSEARCH_" prefix. This is a functional option for NewClient.
 // Example: client, err := elastic.NewClient(elastic.FromEnv())
@@ -330,6 +624,11 @@ func NewClient(options ...ClientOption) (*Client, error) {
 		config.Logger = &NopLogger{}
 	}
 
+	// Set default codec if none provided
+	if config.Codec == nil {
+		config.Codec = JSONCodec{}
+	}
+
 	// Get the first host for logging
 	firstHost := "localhost"
 	logPort := 9200
@@ -358,7 +657,12 @@ func NewClient(options ...ClientOption) (*Client, error) {
 		shutdownChan: make(chan struct{}),
 	}
 
-	if err := client.connect(); err != nil {
+	if config.LazyConnect {
+		if err := client.connectLazily(); err != nil {
+			return nil, err
+		}
+		config.Logger.Info("Lazy connect enabled - skipping initial connectivity check")
+	} else if err := client.connect(); err != nil {
 		return nil, fmt.Errorf("failed to connect to Elasticsearch: %w", err)
 	}
 
@@ -418,6 +722,13 @@ func (c *Client) connect() error {
 		return fmt.Errorf("elasticsearch returned error: %s", res.String())
 	}
 
+	// Cache server details so callers can adapt to the connected version
+	if info, err := decodeServerInfo(res.Body); err != nil {
+		c.config.Logger.Warn("Failed to read server info - error: %s", err.Error())
+	} else {
+		c.serverInfo = info
+	}
+
 	c.client = client
 	c.isConnected = true
 	c.lastReconnect = time.Now()
@@ -425,6 +736,23 @@ func (c *Client) connect() error {
 	return nil
 }
 
+// connectLazily creates the underlying client without testing connectivity. The client
+// starts disconnected and is marked connected by the first successful health check.
+func (c *Client) connectLazily() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	client, err := elasticsearch.NewClient(c.buildClientConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create Elasticsearch client: %w", err)
+	}
+
+	c.client = client
+	c.isConnected = false
+
+	return nil
+}
+
 // buildClientConfig constructs Elasticsearch client configuration
 func (c *Client) buildClientConfig() elasticsearch.Config {
 	config := elasticsearch.Config{
@@ -435,20 +763,24 @@ func (c *Client) buildClientConfig() elasticsearch.Config {
 		CloudID:   c.config.CloudID,
 
 		// Transport settings
-		Transport: &http.Transport{
-			MaxIdleConns:          c.config.MaxIdleConns,
-			MaxIdleConnsPerHost:   c.config.MaxIdleConnsPerHost,
-			IdleConnTimeout:       c.config.IdleConnTimeout,
-			ResponseHeaderTimeout: c.config.RequestTimeout,
-			DisableCompression:    !c.config.CompressionEnabled,
-		},
+		Transport: c.buildTransport(),
 
 		// Retry settings
 		RetryOnStatus: c.config.RetryOnStatus,
 		MaxRetries:    c.config.MaxRetries,
 
 		// Discovery settings
-		DiscoverNodesOnStart: c.config.DiscoverNodesOnStart,
+		DiscoverNodesOnStart:  c.config.DiscoverNodesOnStart,
+		DiscoverNodesInterval: c.config.DiscoverNodesInterval,
+	}
+
+	// Disable transport retries entirely when requested, so callers can
+	// handle retries of non-idempotent operations themselves
+	if c.config.DisableRetry {
+		config.DisableRetry = true
+		config.RetryOnStatus = nil
+		config.MaxRetries = 0
+		return config
 	}
 
 	// Set default retry statuses if not configured
@@ -456,6 +788,13 @@ func (c *Client) buildClientConfig() elasticsearch.Config {
 		config.RetryOnStatus = []int{502, 503, 504, 429}
 	}
 
+	config.RetryBackoff = c.config.RetryBackoff
+	if retryOnError := c.config.RetryOnError; retryOnError != nil {
+		config.RetryOnError = func(_ *http.Request, err error) bool {
+			return retryOnError(err)
+		}
+	}
+
 	return config
 }
 
@@ -468,6 +807,11 @@ func (c *Client) startHealthCheck() {
 	c.healthTicker = time.NewTicker(c.config.HealthCheckInterval)
 
 	go func() {
+		// A lazily connected client checks right away instead of waiting a full interval
+		if c.config.LazyConnect {
+			c.performHealthCheck()
+		}
+
 		for {
 			select {
 			case <-c.healthTicker.C:
@@ -487,21 +831,54 @@ func (c *Client) performHealthCheck() {
 	defer cancel()
 
 	err := c.Ping(ctx)
+	c.recordHealthCheck(err)
 	if err != nil {
 		c.config.Logger.Warn("Health check failed - error: %s", err.Error())
 
 		if c.config.ReconnectEnabled {
 			c.attemptReconnect()
 		}
+		return
+	}
+
+	// A lazily connected client has no server info until the cluster is first reached
+	c.mutex.RLock()
+	missingInfo := c.serverInfo == nil
+	c.mutex.RUnlock()
+	if missingInfo {
+		if _, err := c.RefreshServerInfo(ctx); err != nil {
+			c.config.Logger.Warn("Failed to read server info - error: %s", err.Error())
+		}
+	}
+}
+
+// recordHealthCheck stores the outcome of a health check for Stats and HealthStatus
+// and reports it to the configured health check callback
+func (c *Client) recordHealthCheck(err error) {
+	c.mutex.Lock()
+	c.healthState.lastError = err
+	c.healthState.lastCheck = time.Now()
+	if err != nil {
+		c.healthState.consecutiveFailures++
+	} else {
+		c.healthState.consecutiveFailures = 0
+	}
+	c.mutex.Unlock()
+
+	// Called without the lock held so the callback may use the client (e.g. HealthStatus)
+	if callback := c.config.HealthCheckCallback; callback != nil {
+		callback(err == nil, err)
 	}
 }
 
 // attemptReconnect attempts to reconnect to Elasticsearch
 func (c *Client) attemptReconnect() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	// connect() takes the lock itself, so it must not be held while reconnecting
+	c.mutex.RLock()
+	connected := c.isConnected
+	c.mutex.RUnlock()
 
-	if c.isConnected {
+	if connected {
 		return // Already connected
 	}
 
@@ -513,11 +890,18 @@ func (c *Client) attemptReconnect() {
 
 		c.config.Logger.Info("Attempting to reconnect to Elasticsearch - attempt: %d, max_attempts: %d, delay: %v", attempts, c.config.MaxReconnectAttempts, delay)
 
-		time.Sleep(delay)
+		select {
+		case <-time.After(delay):
+		case <-c.shutdownChan:
+			return
+		}
 
 		if err := c.connect(); err == nil {
-			c.config.Logger.Info("Successfully reconnected to Elasticsearch - attempts: %d", attempts)
+			c.mutex.Lock()
 			c.reconnectCount++
+			c.mutex.Unlock()
+
+			c.config.Logger.Info("Successfully reconnected to Elasticsearch - attempts: %d", attempts)
 			return
 		}
 
@@ -559,6 +943,28 @@ func (c *Client) Name() string {
 	return c.config.ConnectionName
 }
 
+// Codec returns the JSON codec the client encodes and decodes documents with
+func (c *Client) Codec() Codec {
+	return c.codec()
+}
+
+// codec returns the configured JSON codec, falling back to JSONCodec
+func (c *Client) codec() Codec {
+	if c == nil || c.config == nil || c.config.Codec == nil {
+		return JSONCodec{}
+	}
+	return c.config.Codec
+}
+
+// decodeBody reads a response body and unmarshals it into v with the configured codec
+func (c *Client) decodeBody(body io.Reader, v any) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	return c.codec().Unmarshal(data, v)
+}
+
 // Resource-oriented API methods
 
 // Indices returns an IndicesService for index operations
@@ -582,6 +988,13 @@ func (c *Client) Cluster() *ClusterService {
 	}
 }
 
+// Ingest returns an IngestService for ingest pipeline operations
+func (c *Client) Ingest() *IngestService {
+	return &IngestService{
+		client: c,
+	}
+}
+
 // Convenience methods for direct index access
 
 // Search returns an Index instance for search operations
@@ -619,3 +1032,8 @@ type DocumentsService struct {
 type ClusterService struct {
 	client *Client
 }
+
+// IngestService provides operations for ingest pipelines
+type IngestService struct {
+	client *Client
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
 )
 
 // Package-level convenience functions for client creation
@@ -74,9 +77,100 @@ func (c *Client) Stats() ConnectionStats {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
+	open, inUse, idle := c.poolStats.snapshot()
+
 	return ConnectionStats{
-		IsConnected:   c.isConnected,
-		Reconnects:    c.reconnectCount,
-		LastReconnect: c.lastReconnect,
+		IsConnected:          c.isConnected,
+		Reconnects:           c.reconnectCount,
+		LastReconnect:        c.lastReconnect,
+		LastHealthCheckError: c.healthState.lastError,
+		LastHealthCheckTime:  c.healthState.lastCheck,
+		OpenConnections:      open,
+		InUseConnections:     inUse,
+		IdleConnections:      idle,
+	}
+}
+
+// HealthStatus returns a detailed health snapshot including the reason of the last failed health check
+func (c *Client) HealthStatus() HealthStatus {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	status := HealthStatus{
+		Healthy:             c.isConnected && c.healthState.lastError == nil,
+		IsConnected:         c.isConnected,
+		LastCheckTime:       c.healthState.lastCheck,
+		ConsecutiveFailures: c.healthState.consecutiveFailures,
+		Reconnects:          c.reconnectCount,
+		LastReconnect:       c.lastReconnect,
+	}
+
+	if c.healthState.lastError != nil {
+		status.LastError = c.healthState.lastError.Error()
+	}
+
+	return status
+}
+
+// Ready reports whether the client can serve requests, for readiness probes. While background
+// health checks are enabled it answers from the most recent check, so frequent probes add no load
+// on the cluster; when health checks are disabled, none has run yet or the last one is older than
+// two intervals, it pings the cluster instead. Failures wrap ErrNotReady.
+func (c *Client) Ready(ctx context.Context) error {
+	c.mutex.RLock()
+	connected := c.isConnected
+	lastError := c.healthState.lastError
+	lastCheck := c.healthState.lastCheck
+	c.mutex.RUnlock()
+
+	fresh := c.config.HealthCheckEnabled && c.config.HealthCheckInterval > 0 &&
+		!lastCheck.IsZero() && time.Since(lastCheck) <= 2*c.config.HealthCheckInterval
+	if !fresh {
+		if err := c.Ping(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrNotReady, err)
+		}
+		return nil
+	}
+
+	if lastError != nil {
+		return fmt.Errorf("%w: last health check failed: %w", ErrNotReady, lastError)
+	}
+	if !connected {
+		return fmt.Errorf("%w: client not connected", ErrNotReady)
+	}
+	return nil
+}
+
+// Live reports whether the cluster answers at all, for liveness probes. It sends a HEAD request to
+// the root endpoint, which is cheaper than Ping and leaves the client's connection state untouched.
+func (c *Client) Live(ctx context.Context) error {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+	}
+
+	c.mutex.RLock()
+	client := c.client
+	c.mutex.RUnlock()
+
+	if client == nil {
+		return fmt.Errorf("client not connected")
 	}
+
+	res, err := esapi.PingRequest{}.Do(ctx, client)
+	if err != nil {
+		return fmt.Errorf("liveness check failed: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			c.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		return fmt.Errorf("liveness check failed: %s", res.Status())
+	}
+
+	return nil
 }
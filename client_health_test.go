@@ -0,0 +1,128 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthStatusTracksLastError(t *testing.T) {
+	client := &Client{
+		config:      &Config{Logger: &NopLogger{}},
+		isConnected: true,
+	}
+
+	client.recordHealthCheck(errors.New("connection refused"))
+	client.recordHealthCheck(errors.New("connection refused"))
+
+	status := client.HealthStatus()
+	if status.Healthy {
+		t.Error("Expected client to be unhealthy after failed health checks")
+	}
+	if status.LastError != "connection refused" {
+		t.Errorf("Expected last error 'connection refused', got '%s'", status.LastError)
+	}
+	if status.ConsecutiveFailures != 2 {
+		t.Errorf("Expected 2 consecutive failures, got %d", status.ConsecutiveFailures)
+	}
+	if status.LastCheckTime.IsZero() {
+		t.Error("Expected last check time to be set")
+	}
+
+	stats := client.Stats()
+	if stats.LastHealthCheckError == nil {
+		t.Error("Expected Stats() to expose the last health check error")
+	}
+
+	client.recordHealthCheck(nil)
+
+	status = client.HealthStatus()
+	if !status.Healthy || status.LastError != "" || status.ConsecutiveFailures != 0 {
+		t.Errorf("Expected healthy status after successful check, got %+v", status)
+	}
+}
+
+func TestHealthCheckCallback(t *testing.T) {
+	var outcomes []bool
+	var lastErr error
+	client := &Client{
+		config: &Config{Logger: &NopLogger{}},
+	}
+	client.config.HealthCheckCallback = func(healthy bool, err error) {
+		// The client must be usable from within the callback
+		_ = client.HealthStatus()
+		outcomes = append(outcomes, healthy)
+		lastErr = err
+	}
+
+	client.recordHealthCheck(errors.New("connection refused"))
+	if len(outcomes) != 1 || outcomes[0] || lastErr == nil {
+		t.Fatalf("Expected an unhealthy outcome with error, got %v (err: %v)", outcomes, lastErr)
+	}
+
+	client.recordHealthCheck(nil)
+	if len(outcomes) != 2 || !outcomes[1] || lastErr != nil {
+		t.Errorf("Expected a healthy outcome without error, got %v (err: %v)", outcomes, lastErr)
+	}
+}
+
+func TestReadyUsesCachedHealthCheck(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": {"number": "9.1.0"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	// Without a health check result Ready falls back to a ping
+	if err := client.Ready(context.Background()); err != nil {
+		t.Fatalf("Expected client to be ready, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("Expected Ready to ping once, got %d requests", got)
+	}
+
+	client.config.HealthCheckEnabled = true
+	client.config.HealthCheckInterval = time.Minute
+	client.recordHealthCheck(nil)
+	for i := 0; i < 3; i++ {
+		if err := client.Ready(context.Background()); err != nil {
+			t.Fatalf("Expected client to be ready, got %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected Ready to answer from the cached health check, got %d requests", got)
+	}
+
+	client.recordHealthCheck(errors.New("connection refused"))
+	if err := client.Ready(context.Background()); !errors.Is(err, ErrNotReady) {
+		t.Errorf("Expected ErrNotReady after a failed health check, got %v", err)
+	}
+}
+
+func TestLiveSendsHeadRequest(t *testing.T) {
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	if err := client.Live(context.Background()); err != nil {
+		t.Fatalf("Expected cluster to be live, got %v", err)
+	}
+	if method != http.MethodHead || path != "/" {
+		t.Errorf("Expected HEAD /, got %s %s", method, path)
+	}
+}
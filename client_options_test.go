@@ -2,6 +2,7 @@ package elastic
 
 import (
 	"testing"
+	"time"
 )
 
 func TestClientOptions(t *testing.T) {
@@ -128,4 +129,82 @@ func TestClientOptions(t *testing.T) {
 			t.Errorf("Expected hosts ['confighost:9202'], got %v", opts.config.Hosts)
 		}
 	})
+
+	// Test 7: WithNodeDiscovery option
+	t.Run("with node discovery", func(t *testing.T) {
+		opts := &clientOptions{}
+
+		WithNodeDiscovery(true, 5*time.Minute)(opts)
+
+		if !opts.config.DiscoverNodesOnStart {
+			t.Error("Expected DiscoverNodesOnStart to be true")
+		}
+		if opts.config.DiscoverNodesInterval != 5*time.Minute {
+			t.Errorf("Expected DiscoverNodesInterval 5m, got %v", opts.config.DiscoverNodesInterval)
+		}
+
+		client := &Client{config: opts.config}
+		if got := client.buildClientConfig().DiscoverNodesInterval; got != 5*time.Minute {
+			t.Errorf("Expected elasticsearch config DiscoverNodesInterval 5m, got %v", got)
+		}
+	})
+
+	// Test 8: WithRetry option
+	t.Run("with retry disabled", func(t *testing.T) {
+		opts := &clientOptions{}
+
+		WithRetry(false)(opts)
+
+		client := &Client{config: opts.config}
+		esConfig := client.buildClientConfig()
+		if !esConfig.DisableRetry {
+			t.Error("Expected DisableRetry to be true when retries are disabled")
+		}
+		if len(esConfig.RetryOnStatus) != 0 {
+			t.Errorf("Expected no retry statuses, got %v", esConfig.RetryOnStatus)
+		}
+
+		WithRetry(true)(opts)
+		esConfig = client.buildClientConfig()
+		if esConfig.DisableRetry {
+			t.Error("Expected DisableRetry to be false when retries are enabled")
+		}
+	})
+
+	// Test 9: WithRetryBackoff and WithRetryOnError options
+	t.Run("with retry policy", func(t *testing.T) {
+		opts := &clientOptions{config: &Config{}}
+
+		WithRetryBackoff(func(attempt int) time.Duration {
+			return time.Duration(attempt) * time.Second
+		})(opts)
+		WithRetryOnError(func(err error) bool { return false })(opts)
+
+		client := &Client{config: opts.config}
+		esConfig := client.buildClientConfig()
+		if esConfig.RetryBackoff == nil || esConfig.RetryBackoff(3) != 3*time.Second {
+			t.Error("Expected RetryBackoff to be passed to the elasticsearch config")
+		}
+		if esConfig.RetryOnError == nil || esConfig.RetryOnError(nil, nil) {
+			t.Error("Expected RetryOnError to be passed to the elasticsearch config")
+		}
+	})
+	// Test 10: WithLazyConnect creates a client without reaching the cluster
+	t.Run("with lazy connect", func(t *testing.T) {
+		client, err := NewClient(
+			WithConfig(&Config{Hosts: []string{"127.0.0.1:1"}, ConnectTimeout: time.Second}),
+			WithLazyConnect(true),
+		)
+		if err != nil {
+			t.Fatalf("Expected lazy client creation to succeed without a cluster, got %v", err)
+		}
+		defer func() { _ = client.Close() }()
+
+		if client.GetClient() == nil {
+			t.Error("Expected the underlying client to be created")
+		}
+		if client.Stats().IsConnected {
+			t.Error("Expected a lazily connected client to start disconnected")
+		}
+	})
 }
@@ -0,0 +1,143 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RawResponse holds the response of a request made with DoRequest
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// IsError returns true when the response status is 400 or higher
+func (r *RawResponse) IsError() bool {
+	return r.StatusCode >= 400
+}
+
+// Err returns an error describing the response when IsError is true, nil otherwise
+func (r *RawResponse) Err() error {
+	if !r.IsError() {
+		return nil
+	}
+	return fmt.Errorf("request failed: %d %s - %s", r.StatusCode, http.StatusText(r.StatusCode), string(r.Body))
+}
+
+// Decode unmarshals the JSON response body into v
+func (r *RawResponse) Decode(v any) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// String returns the response body as a string
+func (r *RawResponse) String() string {
+	return string(r.Body)
+}
+
+// readEndpoints are the APIs a read-only client may call with POST, keyed by the first path
+// segment starting with "_", e.g. _search in /products/_search/template
+var readEndpoints = map[string]bool{
+	"_search":     true,
+	"_msearch":    true,
+	"_count":      true,
+	"_mget":       true,
+	"_field_caps": true,
+	"_validate":   true,
+	"_explain":    true,
+	"_pit":        true,
+	"_terms_enum": true,
+	"_render":     true,
+}
+
+// isReadRequest reports whether a DoRequest request only reads: GET and HEAD, POST to a search-like
+// endpoint, and DELETE of a scroll or point in time, which only frees search resources
+func isReadRequest(method, path string) bool {
+	endpoint := ""
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "_") {
+			endpoint = segment
+			break
+		}
+	}
+
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return readEndpoints[endpoint]
+	case http.MethodDelete:
+		return endpoint == "_search" || endpoint == "_pit"
+	}
+	return false
+}
+
+// DoRequest sends a request to an endpoint the library doesn't wrap yet, e.g.
+// DoRequest(ctx, http.MethodGet, "/_nodes/hot_threads?threads=5", nil). It goes through the
+// client's transport, so authentication, retries, node selection and logging still apply.
+// The response body is read and closed; error statuses are returned as a response, see RawResponse.Err.
+// A read-only client only sends reads (see isReadRequest) and rejects anything else with ErrReadOnly.
+func (c *Client) DoRequest(ctx context.Context, method, path string, body io.Reader) (*RawResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	client := c.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	target, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path '%s': %w", path, err)
+	}
+	if !isReadRequest(method, target.Path) {
+		if err := c.checkWritable(method + " " + target.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Perform(req)
+	if err != nil {
+		c.config.Logger.Error("Raw request failed - method: %s, path: %s, error: %s", method, target.Path, err.Error())
+		return nil, fmt.Errorf("%s %s request failed: %w", method, target.Path, err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			c.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	c.config.Logger.Debug("Raw request completed - method: %s, path: %s, status: %d", method, target.Path, res.StatusCode)
+
+	return &RawResponse{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       responseBody,
+	}, nil
+}
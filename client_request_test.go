@@ -0,0 +1,106 @@
+package elastic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/_custom/endpoint":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || r.URL.Query().Get("verbose") != "true" || string(body) != `{"a":1}` {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"unexpected request"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	res, err := client.DoRequest(context.Background(), http.MethodPost, "/_custom/endpoint?verbose=true", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.IsError() || res.Err() != nil {
+		t.Fatalf("Expected success, got %d: %s", res.StatusCode, res.String())
+	}
+
+	var decoded struct {
+		Acknowledged bool `json:"acknowledged"`
+	}
+	if err := res.Decode(&decoded); err != nil || !decoded.Acknowledged {
+		t.Errorf("Expected acknowledged response, got %v (%v)", decoded, err)
+	}
+
+	missing, err := client.DoRequest(context.Background(), http.MethodGet, "/_missing", nil)
+	if err != nil {
+		t.Fatalf("Unexpected transport error: %v", err)
+	}
+	if missing.StatusCode != http.StatusNotFound || missing.Err() == nil {
+		t.Errorf("Expected a 404 response with an error, got %d", missing.StatusCode)
+	}
+}
+
+// newTestServerClient creates a lazily connected client for an httptest server, closed when the test ends.
+// Handlers must set the X-Elastic-Product header for the transport to accept responses.
+func newTestServerClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:       []string{strings.TrimPrefix(server.URL, "http://")},
+		LazyConnect: true,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestWithConfigRetriesByDefault(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Header().Set("Content-Type", "application/json")
+			if calls == 1 {
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"error": "busy"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		}))
+
+		// A Config literal leaves DisableRetry unset, which must keep transport retries on
+		client := newTestServerClient(t, server)
+
+		res, err := client.DoRequest(context.Background(), http.MethodGet, "/_custom/endpoint", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error for status %d: %v", status, err)
+		}
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200 after retrying %d, got %d", status, res.StatusCode)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests for status %d, got %d", status, calls)
+		}
+		server.Close()
+	}
+}
@@ -14,6 +14,16 @@ type Shutdownable interface {
 	Close() error
 }
 
+// ContextShutdownable is implemented by resources that can shut down within a deadline, such as
+// a BulkProcessor draining its queue; ShutdownManager passes its shutdown context to CloseContext
+type ContextShutdownable interface {
+	CloseContext(ctx context.Context) error
+}
+
+// ShutdownHook is a callback executed before registered clients are closed.
+// The context expires when the shutdown timeout is reached.
+type ShutdownHook func(ctx context.Context) error
+
 // ShutdownConfig holds configuration for graceful shutdown
 type ShutdownConfig struct {
 	Timeout          time.Duration // Maximum time to wait for shutdown
@@ -22,10 +32,22 @@ type ShutdownConfig struct {
 }
 
 // ShutdownManager manages graceful shutdown of Elasticsearch clients and other resources
+//
+// Shutdown runs in ordered phases so no pending writes are lost:
+//  1. hooks registered with OnShutdown
+//  2. resources registered with RegisterResources
+//  3. processors (e.g. BulkProcessor) are drained
+//  4. clients are closed
 type ShutdownManager struct {
 	clients      []*Client
 	resources    []Shutdownable
+	processors   []Shutdownable
+	hooks        []ShutdownHook
 	shutdownChan chan os.Signal
+	triggerChan  chan struct{}
+	triggerOnce  sync.Once
+	shutdownOnce sync.Once
+	done         chan struct{}
 	ctx          context.Context
 	cancel       context.CancelFunc
 	mutex        sync.Mutex
@@ -54,7 +76,10 @@ func NewShutdownManager(config *ShutdownConfig, logger Logger) *ShutdownManager
 	return &ShutdownManager{
 		clients:      make([]*Client, 0),
 		resources:    make([]Shutdownable, 0),
+		processors:   make([]Shutdownable, 0),
 		shutdownChan: make(chan os.Signal, 1),
+		triggerChan:  make(chan struct{}),
+		done:         make(chan struct{}),
 		ctx:          ctx,
 		cancel:       cancel,
 		config:       config,
@@ -92,31 +117,86 @@ func (sm *ShutdownManager) SetupSignalHandler() {
 	sm.logger.Info("Signal handlers setup for graceful shutdown")
 }
 
-// Wait blocks until a shutdown signal is received and performs graceful shutdown
+// Wait blocks until a shutdown signal is received or Trigger is called, then performs graceful shutdown
 func (sm *ShutdownManager) Wait() {
-	sig := <-sm.shutdownChan
-	sm.logger.Info("Received shutdown signal - signal: %s", sig.String())
+	select {
+	case sig := <-sm.shutdownChan:
+		sm.logger.Info("Received shutdown signal - signal: %s", sig.String())
+	case <-sm.triggerChan:
+		sm.logger.Info("Shutdown triggered programmatically")
+	}
 
 	sm.shutdown()
 }
 
+// Trigger initiates shutdown without an OS signal, e.g. when a critical dependency fails.
+// Wait returns once shutdown has completed. Calling Trigger more than once has no effect.
+func (sm *ShutdownManager) Trigger() {
+	sm.triggerOnce.Do(func() {
+		close(sm.triggerChan)
+	})
+}
+
+// Done returns a channel that is closed once graceful shutdown has completed
+func (sm *ShutdownManager) Done() <-chan struct{} {
+	return sm.done
+}
+
 // Context returns the shutdown manager's context for background workers
 func (sm *ShutdownManager) Context() context.Context {
 	return sm.ctx
 }
 
 // RegisterResources registers shutdownable resources for graceful shutdown
+// A *BulkProcessor passed here is drained in the processors phase, after other resources.
 func (sm *ShutdownManager) RegisterResources(resources ...Shutdownable) {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
-	sm.resources = append(sm.resources, resources...)
+	for _, resource := range resources {
+		if processor, ok := resource.(*BulkProcessor); ok {
+			sm.processors = append(sm.processors, processor)
+			continue
+		}
+		sm.resources = append(sm.resources, resource)
+	}
 
 	sm.logger.Info("Registered resources for graceful shutdown - count: %d", len(resources))
 }
 
-// shutdown performs the actual shutdown logic
+// RegisterProcessors registers processors that buffer writes (e.g. BulkProcessor).
+// They are closed after other resources and before clients, so queued operations are flushed
+// while the connection is still available.
+func (sm *ShutdownManager) RegisterProcessors(processors ...Shutdownable) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.processors = append(sm.processors, processors...)
+
+	sm.logger.Info("Registered processors for graceful shutdown - count: %d", len(processors))
+}
+
+// OnShutdown registers hooks that run before clients and resources are closed,
+// e.g. to flush a bulk processor or drain a queue. Hooks run in registration order.
+func (sm *ShutdownManager) OnShutdown(hooks ...ShutdownHook) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.hooks = append(sm.hooks, hooks...)
+
+	sm.logger.Info("Registered shutdown hooks - count: %d", len(hooks))
+}
+
+// shutdown performs the shutdown logic exactly once and then closes the done channel
 func (sm *ShutdownManager) shutdown() {
+	sm.shutdownOnce.Do(func() {
+		sm.performShutdown()
+		close(sm.done)
+	})
+}
+
+// performShutdown performs the actual shutdown logic
+func (sm *ShutdownManager) performShutdown() {
 	start := time.Now()
 
 	sm.logger.Info("Starting graceful shutdown - timeout: %v", sm.config.Timeout)
@@ -139,27 +219,31 @@ func (sm *ShutdownManager) shutdown() {
 		copy(clients, sm.clients)
 		resources := make([]Shutdownable, len(sm.resources))
 		copy(resources, sm.resources)
+		processors := make([]Shutdownable, len(sm.processors))
+		copy(processors, sm.processors)
+		hooks := make([]ShutdownHook, len(sm.hooks))
+		copy(hooks, sm.hooks)
 		sm.mutex.Unlock()
 
-		// Close Elasticsearch clients
-		for i, client := range clients {
-			if client != nil {
-				sm.logger.Info("Closing Elasticsearch client - client_index: %d", i)
+		// Run pre-shutdown hooks while the clients are still usable
+		for i, hook := range hooks {
+			if hook == nil {
+				continue
+			}
 
-				if err := client.Close(); err != nil {
-					sm.logger.Error("Error closing Elasticsearch client - client_index: %d, error: %s", i, err.Error())
-				} else {
-					sm.logger.Info("Elasticsearch client closed successfully - client_index: %d", i)
-				}
+			sm.logger.Info("Running shutdown hook - hook_index: %d", i)
+
+			if err := hook(shutdownCtx); err != nil {
+				sm.logger.Error("Shutdown hook failed - hook_index: %d, error: %s", i, err.Error())
 			}
 		}
 
-		// Close other resources
+		// Close resources first so they stop producing new work
 		for i, resource := range resources {
 			if resource != nil {
 				sm.logger.Info("Closing resource - resource_index: %d", i)
 
-				if err := resource.Close(); err != nil {
+				if err := closeWithContext(shutdownCtx, resource); err != nil {
 					sm.logger.Error("Error closing resource - resource_index: %d, error: %s", i, err.Error())
 				} else {
 					sm.logger.Info("Resource closed successfully - resource_index: %d", i)
@@ -167,6 +251,32 @@ func (sm *ShutdownManager) shutdown() {
 			}
 		}
 
+		// Drain processors while the clients are still connected
+		for i, processor := range processors {
+			if processor != nil {
+				sm.logger.Info("Draining processor - processor_index: %d", i)
+
+				if err := closeWithContext(shutdownCtx, processor); err != nil {
+					sm.logger.Error("Error draining processor - processor_index: %d, error: %s", i, err.Error())
+				} else {
+					sm.logger.Info("Processor drained successfully - processor_index: %d", i)
+				}
+			}
+		}
+
+		// Close Elasticsearch clients last
+		for i, client := range clients {
+			if client != nil {
+				sm.logger.Info("Closing Elasticsearch client - client_index: %d", i)
+
+				if err := client.Close(); err != nil {
+					sm.logger.Error("Error closing Elasticsearch client - client_index: %d, error: %s", i, err.Error())
+				} else {
+					sm.logger.Info("Elasticsearch client closed successfully - client_index: %d", i)
+				}
+			}
+		}
+
 		// Wait for grace period to allow in-flight operations to complete
 		if sm.config.GracePeriod > 0 {
 			sm.logger.Info("Waiting grace period for in-flight operations - grace_period: %v", sm.config.GracePeriod)
@@ -224,9 +334,31 @@ func (sm *ShutdownManager) GetClientCount() int {
 	return len(sm.clients)
 }
 
+// GetProcessorCount returns the number of registered processors
+func (sm *ShutdownManager) GetProcessorCount() int {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	return len(sm.processors)
+}
+
+// GetHookCount returns the number of registered shutdown hooks
+func (sm *ShutdownManager) GetHookCount() int {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	return len(sm.hooks)
+}
+
 // GetResourceCount returns the number of registered resources
 func (sm *ShutdownManager) GetResourceCount() int {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 	return len(sm.resources)
 }
+
+// closeWithContext closes a resource within the shutdown deadline when it supports one
+func closeWithContext(ctx context.Context, resource Shutdownable) error {
+	if contextual, ok := resource.(ContextShutdownable); ok {
+		return contextual.CloseContext(ctx)
+	}
+	return resource.Close()
+}
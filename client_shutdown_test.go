@@ -0,0 +1,137 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdownHooksRunBeforeResourcesClose(t *testing.T) {
+	sm := NewShutdownManager(&ShutdownConfig{Timeout: 5 * time.Second}, nil)
+
+	var order []string
+	sm.OnShutdown(func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("Expected shutdown hook context to carry the shutdown timeout")
+		}
+		order = append(order, "hook-1")
+		return errors.New("hook failure should not stop shutdown")
+	})
+	sm.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "hook-2")
+		return nil
+	})
+	sm.RegisterResources(shutdownFunc(func() error {
+		order = append(order, "resource")
+		return nil
+	}))
+
+	if sm.GetHookCount() != 2 {
+		t.Errorf("Expected 2 hooks, got %d", sm.GetHookCount())
+	}
+
+	sm.shutdown()
+
+	expected := []string{"hook-1", "hook-2", "resource"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+// shutdownFunc adapts a function to the Shutdownable interface
+type shutdownFunc func() error
+
+func (f shutdownFunc) Close() error {
+	return f()
+}
+
+func TestShutdownPhaseOrdering(t *testing.T) {
+	sm := NewShutdownManager(&ShutdownConfig{Timeout: 5 * time.Second}, nil)
+
+	client := &Client{config: &Config{Logger: &NopLogger{}}, shutdownChan: make(chan struct{})}
+	clientClosed := func() bool {
+		select {
+		case <-client.shutdownChan:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var order []string
+	var sent []*BulkOperation
+
+	processor := newBulkProcessor(client, "events", BulkProcessorConfig{BatchSize: 100},
+		func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+			if clientClosed() {
+				t.Error("Expected processor to be drained before the client is closed")
+			}
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("Expected the drain to run within the shutdown deadline")
+			}
+			order = append(order, "processor")
+			sent = append(sent, operations...)
+			return &BulkResponse{}, nil
+		})
+	if err := processor.Index("1", map[string]any{"message": "pending"}); err != nil {
+		t.Fatalf("Failed to queue operation: %v", err)
+	}
+
+	sm.Register(client)
+	// A BulkProcessor registered as a plain resource is still drained in the processors phase
+	sm.RegisterResources(processor, shutdownFunc(func() error {
+		order = append(order, "resource")
+		return nil
+	}))
+
+	sm.shutdown()
+
+	if !clientClosed() {
+		t.Error("Expected client to be closed")
+	}
+	if len(order) != 2 || order[0] != "resource" || order[1] != "processor" {
+		t.Errorf("Expected [resource processor], got %v", order)
+	}
+	if len(sent) != 1 || sent[0].Index != "events" {
+		t.Errorf("Expected the pending operation to be flushed to 'events', got %v", sent)
+	}
+	if err := processor.Index("2", map[string]any{}); err != ErrBulkProcessorClosed {
+		t.Errorf("Expected ErrBulkProcessorClosed after shutdown, got %v", err)
+	}
+}
+
+func TestShutdownTrigger(t *testing.T) {
+	sm := NewShutdownManager(&ShutdownConfig{Timeout: 5 * time.Second}, nil)
+
+	waitReturned := make(chan struct{})
+	go func() {
+		sm.Wait()
+		close(waitReturned)
+	}()
+
+	sm.Trigger()
+	sm.Trigger() // must be safe to call twice
+
+	select {
+	case <-sm.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Done to be closed after Trigger")
+	}
+
+	select {
+	case <-waitReturned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Wait to return after Trigger")
+	}
+
+	if sm.Context().Err() == nil {
+		t.Error("Expected the shutdown context to be cancelled")
+	}
+}
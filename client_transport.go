@@ -0,0 +1,310 @@
+package elastic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// buildTransport builds the HTTP transport used by the Elasticsearch client
+func (c *Client) buildTransport() http.RoundTripper {
+	// The pool counters outlive a single transport so reconnects keep accounting for old connections
+	if c.poolStats == nil {
+		c.poolStats = &connPoolStats{}
+	}
+
+	roundTripper := c.config.Transport
+	if roundTripper == nil {
+		roundTripper = c.buildPooledTransport()
+	}
+
+	if c.config.RequestCompressionThreshold > 0 {
+		roundTripper = &gzipRequestTransport{
+			next:      roundTripper,
+			threshold: c.config.RequestCompressionThreshold,
+		}
+	}
+
+	return &poolStatsTransport{
+		next:  roundTripper,
+		stats: c.poolStats,
+	}
+}
+
+// buildPooledTransport builds the connection-pooling transport configured by the connection settings
+func (c *Client) buildPooledTransport() http.RoundTripper {
+	// ConnectTimeout bounds connection establishment for every request, not just the startup check
+	dialer := &net.Dialer{
+		Timeout: c.config.ConnectTimeout,
+	}
+
+	dial := countConnections(dialer.DialContext, c.poolStats)
+
+	transport := &http.Transport{
+		DialContext:           dial,
+		TLSHandshakeTimeout:   c.config.ConnectTimeout,
+		MaxIdleConns:          c.config.MaxIdleConns,
+		MaxIdleConnsPerHost:   c.config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       c.config.IdleConnTimeout,
+		ResponseHeaderTimeout: c.config.RequestTimeout,
+		DisableCompression:    !c.config.CompressionEnabled,
+	}
+
+	if c.config.MaxConnLifetime > 0 {
+		transport.DialContext = dialWithCreationTime(dial)
+		return &connLifetimeTransport{
+			transport:   transport,
+			maxLifetime: c.config.MaxConnLifetime,
+		}
+	}
+
+	return transport
+}
+
+// connPoolStats tracks the connections opened by the transport and how many of them are serving a request
+type connPoolStats struct {
+	open  atomic.Int64
+	inUse atomic.Int64
+}
+
+// snapshot returns the open, in-use and idle connection counts
+func (s *connPoolStats) snapshot() (open, inUse, idle int64) {
+	if s == nil {
+		return 0, 0, 0
+	}
+	open = s.open.Load()
+	inUse = s.inUse.Load()
+	idle = open - inUse
+	if idle < 0 {
+		// The counters are read separately, so a connection may be caught between them
+		idle = 0
+	}
+	return open, inUse, idle
+}
+
+// countedConn is a connection that leaves the open count once it is closed
+type countedConn struct {
+	net.Conn
+	stats     *connPoolStats
+	closeOnce sync.Once
+}
+
+// Close closes the connection and decrements the open count
+func (c *countedConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.stats.open.Add(-1)
+	})
+	return c.Conn.Close()
+}
+
+// countConnections wraps a dial function so every established connection is counted as open until closed
+func countConnections(dial func(ctx context.Context, network, address string) (net.Conn, error), stats *connPoolStats) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		stats.open.Add(1)
+		return &countedConn{Conn: conn, stats: stats}, nil
+	}
+}
+
+// poolStatsTransport counts a connection as in use from the moment a request obtains it until
+// the response body is fully read or closed, which is when net/http returns it to the idle pool.
+// With HTTP/2 several requests share one connection, so the count is per request in that case.
+type poolStatsTransport struct {
+	next  http.RoundTripper
+	stats *connPoolStats
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *poolStatsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var gotConn atomic.Bool
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) {
+			// GotConn fires again when a request is retried on a new connection
+			if gotConn.CompareAndSwap(false, true) {
+				t.stats.inUse.Add(1)
+			}
+		},
+	}
+
+	// Clone the request since a RoundTripper must not modify the caller's request
+	outgoing := req.Clone(httptrace.WithClientTrace(req.Context(), trace))
+	res, err := t.next.RoundTrip(outgoing)
+	if err != nil || res.Body == nil {
+		if gotConn.Load() {
+			t.stats.inUse.Add(-1)
+		}
+		return res, err
+	}
+
+	if gotConn.Load() {
+		res.Body = &inUseBody{ReadCloser: res.Body, stats: t.stats}
+	}
+	return res, nil
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (t *poolStatsTransport) CloseIdleConnections() {
+	if closer, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// inUseBody releases the in-use count of its connection at EOF or on Close, whichever comes first
+type inUseBody struct {
+	io.ReadCloser
+	stats       *connPoolStats
+	releaseOnce sync.Once
+}
+
+// Read implements io.Reader
+func (b *inUseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.release()
+	}
+	return n, err
+}
+
+// Close closes the body and releases the connection
+func (b *inUseBody) Close() error {
+	b.release()
+	return b.ReadCloser.Close()
+}
+
+// release decrements the in-use count once
+func (b *inUseBody) release() {
+	b.releaseOnce.Do(func() {
+		b.stats.inUse.Add(-1)
+	})
+}
+
+// timedConn is a connection that remembers when it was established
+type timedConn struct {
+	net.Conn
+	createdAt time.Time
+}
+
+// dialWithCreationTime wraps a dial function so connections record their creation time
+func dialWithCreationTime(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		return &timedConn{Conn: conn, createdAt: time.Now()}, nil
+	}
+}
+
+// connLifetimeTransport retires connections older than maxLifetime. net/http has no such
+// setting, so a request that gets an expired connection is sent with "Connection: close":
+// it completes normally, the connection is closed afterwards and the next request dials a
+// fresh one. This lets connections behind a load balancer be rebalanced over time.
+type connLifetimeTransport struct {
+	transport   *http.Transport
+	maxLifetime time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *connLifetimeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var outgoing *http.Request
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if t.expired(info.Conn) {
+				outgoing.Close = true
+			}
+		},
+	}
+
+	// Clone the request since a RoundTripper must not modify the caller's request
+	outgoing = req.Clone(httptrace.WithClientTrace(req.Context(), trace))
+	return t.transport.RoundTrip(outgoing)
+}
+
+// CloseIdleConnections closes the idle connections of the underlying transport
+func (t *connLifetimeTransport) CloseIdleConnections() {
+	t.transport.CloseIdleConnections()
+}
+
+// expired reports whether a connection has outlived maxLifetime
+func (t *connLifetimeTransport) expired(conn net.Conn) bool {
+	// TLS connections wrap the dialed connection
+	if wrapper, ok := conn.(interface{ NetConn() net.Conn }); ok {
+		conn = wrapper.NetConn()
+	}
+
+	timed, ok := conn.(*timedConn)
+	if !ok {
+		return false
+	}
+	return time.Since(timed.createdAt) >= t.maxLifetime
+}
+
+// gzipRequestTransport compresses request bodies of at least threshold bytes and sends them with
+// "Content-Encoding: gzip", which Elasticsearch accepts on every endpoint. Large bulk uploads and
+// queries shrink considerably, while small requests skip the compression overhead.
+type gzipRequestTransport struct {
+	next      http.RoundTripper
+	threshold int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *gzipRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return t.next.RoundTrip(req)
+	}
+	if req.ContentLength > 0 && req.ContentLength < int64(t.threshold) {
+		return t.next.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	// Clone the request since a RoundTripper must not modify the caller's request
+	outgoing := req.Clone(req.Context())
+
+	if len(body) < t.threshold {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+		return t.next.RoundTrip(outgoing)
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	outgoing.Body = io.NopCloser(bytes.NewReader(compressed.Bytes()))
+	outgoing.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed.Bytes())), nil
+	}
+	outgoing.ContentLength = int64(compressed.Len())
+	outgoing.Header.Set("Content-Encoding", "gzip")
+
+	return t.next.RoundTrip(outgoing)
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (t *gzipRequestTransport) CloseIdleConnections() {
+	if closer, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
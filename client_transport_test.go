@@ -0,0 +1,178 @@
+package elastic
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConnLifetimeTransportRecyclesConnections(t *testing.T) {
+	var mu sync.Mutex
+	var remoteAddrs []string
+	var closeRequested []bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		remoteAddrs = append(remoteAddrs, r.RemoteAddr)
+		closeRequested = append(closeRequested, r.Close)
+		mu.Unlock()
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:               []string{strings.TrimPrefix(server.URL, "http://")},
+		LazyConnect:         true,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 10,
+		MaxConnLifetime:     50 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.DoRequest(context.Background(), http.MethodGet, "/", nil); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+		if i == 0 {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if remoteAddrs[0] != remoteAddrs[1] {
+		t.Errorf("Expected the second request to reuse the first connection, got %v", remoteAddrs)
+	}
+	if closeRequested[0] || !closeRequested[1] {
+		t.Errorf("Expected only the request on the expired connection to ask for close, got %v", closeRequested)
+	}
+	if remoteAddrs[2] == remoteAddrs[1] {
+		t.Errorf("Expected a fresh connection after the expired one was retired, got %v", remoteAddrs)
+	}
+}
+
+func TestBuildTransportAppliesConnectTimeout(t *testing.T) {
+	client := &Client{config: &Config{ConnectTimeout: 2 * time.Second}}
+
+	wrapper, ok := client.buildTransport().(*poolStatsTransport)
+	if !ok {
+		t.Fatal("Expected the transport to track connection pool statistics")
+	}
+	transport, ok := wrapper.next.(*http.Transport)
+	if !ok {
+		t.Fatal("Expected a plain *http.Transport without MaxConnLifetime")
+	}
+	if transport.DialContext == nil {
+		t.Error("Expected a dialer bounded by ConnectTimeout")
+	}
+	if transport.TLSHandshakeTimeout != 2*time.Second {
+		t.Errorf("Expected TLS handshake timeout 2s, got %v", transport.TLSHandshakeTimeout)
+	}
+}
+
+func TestRequestCompressionThreshold(t *testing.T) {
+	var encodings []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("Failed to open gzip body: %v", err)
+				return
+			}
+			reader = gz
+		}
+		body, _ := io.ReadAll(reader)
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		bodies = append(bodies, string(body))
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:                       []string{strings.TrimPrefix(server.URL, "http://")},
+		LazyConnect:                 true,
+		RequestCompressionThreshold: 1024,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	small := `{"query":{"match_all":{}}}`
+	large := `{"query":{"terms":{"id":["` + strings.Repeat("x", 4096) + `"]}}}`
+	for _, body := range []string{small, large} {
+		if _, err := client.DoRequest(context.Background(), http.MethodPost, "/_search", strings.NewReader(body)); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+
+	if encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("Expected only the large body to be gzipped, got encodings %q", encodings)
+	}
+	if bodies[0] != small || bodies[1] != large {
+		t.Error("Expected both bodies to arrive unchanged after decompression")
+	}
+}
+
+func TestStatsReportsConnectionPool(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-release
+		}
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:               []string{strings.TrimPrefix(server.URL, "http://")},
+		LazyConnect:         true,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 10,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.DoRequest(context.Background(), http.MethodGet, "/slow", nil)
+		done <- err
+	}()
+	<-started
+
+	stats := client.Stats()
+	if stats.OpenConnections != 1 || stats.InUseConnections != 1 || stats.IdleConnections != 0 {
+		t.Errorf("Expected 1 open, 1 in use and 0 idle connections during the request, got %d/%d/%d", stats.OpenConnections, stats.InUseConnections, stats.IdleConnections)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	stats = client.Stats()
+	if stats.OpenConnections != 1 || stats.InUseConnections != 0 || stats.IdleConnections != 1 {
+		t.Errorf("Expected 1 open, 0 in use and 1 idle connection after the request, got %d/%d/%d", stats.OpenConnections, stats.InUseConnections, stats.IdleConnections)
+	}
+}
@@ -92,6 +92,14 @@ func (cr *ClusterResource) Stats(ctx context.Context) (*ClusterStats, error) {
 
 // CreateTemplate creates an index template
 func (cr *ClusterResource) CreateTemplate(ctx context.Context, name string, template map[string]any) error {
+	if err := cr.client.checkWritable("create index template"); err != nil {
+		return err
+	}
+
+	if err := cr.client.requireFeature(FeatureComposableTemplates); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -143,6 +151,10 @@ func (cr *ClusterResource) CreateTemplate(ctx context.Context, name string, temp
 
 // GetTemplate retrieves an index template
 func (cr *ClusterResource) GetTemplate(ctx context.Context, name string) (map[string]any, error) {
+	if err := cr.client.requireFeature(FeatureComposableTemplates); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -180,6 +192,14 @@ func (cr *ClusterResource) GetTemplate(ctx context.Context, name string) (map[st
 
 // DeleteTemplate deletes an index template
 func (cr *ClusterResource) DeleteTemplate(ctx context.Context, name string) error {
+	if err := cr.client.checkWritable("delete index template"); err != nil {
+		return err
+	}
+
+	if err := cr.client.requireFeature(FeatureComposableTemplates); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -225,6 +245,10 @@ func (cr *ClusterResource) DeleteTemplate(ctx context.Context, name string) erro
 
 // ListTemplates lists all index templates
 func (cr *ClusterResource) ListTemplates(ctx context.Context) (map[string]any, error) {
+	if err := cr.client.requireFeature(FeatureComposableTemplates); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -305,7 +329,7 @@ func (cr *ClusterResource) Settings(ctx context.Context) (map[string]any, error)
 }
 
 // AllocationExplain explains why a shard is unassigned or can't be moved
-func (cr *ClusterResource) AllocationExplain(ctx context.Context, body map[string]any) (map[string]any, error) {
+func (cr *ClusterResource) AllocationExplain(ctx context.Context, body map[string]any) (*AllocationExplanation, error) {
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -346,12 +370,29 @@ func (cr *ClusterResource) AllocationExplain(ctx context.Context, body map[strin
 		return nil, fmt.Errorf("allocation explain request failed: %s - %s", res.Status(), string(bodyBytes))
 	}
 
-	var result map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode allocation explain response: %w", err)
+	responseBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allocation explain response: %w", err)
+	}
+
+	explanation, err := decodeAllocationExplanation(responseBytes)
+	if err != nil {
+		return nil, err
 	}
 
 	cr.client.config.Logger.Debug("Allocation explanation retrieved successfully", nil)
 
-	return result, nil
+	return explanation, nil
+}
+
+// decodeAllocationExplanation decodes an allocation explain response, keeping the raw map as a fallback
+func decodeAllocationExplanation(data []byte) (*AllocationExplanation, error) {
+	var explanation AllocationExplanation
+	if err := json.Unmarshal(data, &explanation); err != nil {
+		return nil, fmt.Errorf("failed to decode allocation explain response: %w", err)
+	}
+	if err := json.Unmarshal(data, &explanation.Raw); err != nil {
+		return nil, fmt.Errorf("failed to decode allocation explain response: %w", err)
+	}
+	return &explanation, nil
 }
@@ -0,0 +1,45 @@
+package elastic
+
+import "testing"
+
+func TestDecodeAllocationExplanation(t *testing.T) {
+	body := []byte(`{
+		"index": "my-index",
+		"shard": 0,
+		"primary": false,
+		"current_state": "unassigned",
+		"unassigned_info": {"reason": "NODE_LEFT", "at": "2024-01-01T00:00:00.000Z", "last_allocation_status": "no_attempt"},
+		"can_allocate": "no",
+		"allocate_explanation": "cannot allocate because allocation is not permitted to any of the nodes",
+		"node_allocation_decisions": [
+			{
+				"node_id": "abc",
+				"node_name": "node-1",
+				"node_decision": "no",
+				"weight_ranking": 1,
+				"deciders": [{"decider": "same_shard", "decision": "NO", "explanation": "a copy of this shard is already allocated to this node"}]
+			}
+		]
+	}`)
+
+	explanation, err := decodeAllocationExplanation(body)
+	if err != nil {
+		t.Fatalf("Failed to decode allocation explanation: %v", err)
+	}
+
+	if explanation.Index != "my-index" || explanation.CurrentState != "unassigned" || explanation.Primary {
+		t.Errorf("Unexpected shard details: %+v", explanation)
+	}
+	if explanation.UnassignedInfo == nil || explanation.UnassignedInfo.Reason != "NODE_LEFT" {
+		t.Errorf("Expected unassigned reason NODE_LEFT, got %+v", explanation.UnassignedInfo)
+	}
+	if len(explanation.NodeAllocationDecisions) != 1 || len(explanation.NodeAllocationDecisions[0].Deciders) != 1 {
+		t.Fatalf("Expected one node decision with one decider, got %+v", explanation.NodeAllocationDecisions)
+	}
+	if explanation.NodeAllocationDecisions[0].Deciders[0].Decider != "same_shard" {
+		t.Errorf("Expected decider same_shard, got %s", explanation.NodeAllocationDecisions[0].Deciders[0].Decider)
+	}
+	if explanation.Raw["can_allocate"] != "no" {
+		t.Errorf("Expected raw map to hold the full response, got %v", explanation.Raw)
+	}
+}
@@ -31,7 +31,7 @@ func (s *ClusterService) Settings(ctx context.Context) (map[string]any, error) {
 }
 
 // AllocationExplain explains why a shard is unassigned or can't be moved
-func (s *ClusterService) AllocationExplain(ctx context.Context, options ...map[string]any) (map[string]any, error) {
+func (s *ClusterService) AllocationExplain(ctx context.Context, options ...map[string]any) (*AllocationExplanation, error) {
 	clusterResource := &ClusterResource{
 		client: s.client,
 	}
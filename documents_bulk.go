@@ -1,6 +1,12 @@
 package elastic
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
 
 // DocumentsService bulk methods
 
@@ -21,59 +27,70 @@ type BulkIndexer struct {
 }
 
 // Create adds a create operation to the bulk request (fails if document exists)
-func (bi *BulkIndexer) Create(document any) *BulkIndexer {
+func (bi *BulkIndexer) Create(document any, options ...WriteOption) *BulkIndexer {
 	op := &BulkOperation{
-		Action:   "create",
-		Index:    bi.index,
-		Document: document,
+		Action:       "create",
+		Index:        bi.index,
+		Document:     document,
+		RequireAlias: buildWriteOptions(options).requireAlias,
 	}
 	bi.operations = append(bi.operations, op)
 	return bi
 }
 
 // CreateWithID adds a create operation with specific ID to the bulk request
-func (bi *BulkIndexer) CreateWithID(id string, document any) *BulkIndexer {
+func (bi *BulkIndexer) CreateWithID(id string, document any, options ...WriteOption) *BulkIndexer {
 	op := &BulkOperation{
-		Action:   "create",
-		Index:    bi.index,
-		ID:       id,
-		Document: document,
+		Action:       "create",
+		Index:        bi.index,
+		ID:           id,
+		Document:     document,
+		RequireAlias: buildWriteOptions(options).requireAlias,
 	}
 	bi.operations = append(bi.operations, op)
 	return bi
 }
 
 // Index adds an index operation to the bulk request (creates or replaces)
-func (bi *BulkIndexer) Index(id string, document any) *BulkIndexer {
+func (bi *BulkIndexer) Index(id string, document any, options ...WriteOption) *BulkIndexer {
 	op := &BulkOperation{
-		Action:   "index",
-		Index:    bi.index,
-		ID:       id,
-		Document: document,
+		Action:       "index",
+		Index:        bi.index,
+		ID:           id,
+		Document:     document,
+		RequireAlias: buildWriteOptions(options).requireAlias,
 	}
 	bi.operations = append(bi.operations, op)
 	return bi
 }
 
-// Update adds an update operation to the bulk request
-func (bi *BulkIndexer) Update(id string, document any) *BulkIndexer {
+// Update adds an update operation to the bulk request. With WithUpdateSource(true) the item's
+// BulkItemResult.Source holds the updated document, or the current one if the update conflicted.
+func (bi *BulkIndexer) Update(id string, document any, options ...UpdateOption) *BulkIndexer {
+	opts := buildUpdateOptions(options)
 	op := &BulkOperation{
-		Action:   "update",
-		Index:    bi.index,
-		ID:       id,
-		Document: document,
+		Action:          "update",
+		Index:           bi.index,
+		ID:              id,
+		Document:        document,
+		RetryOnConflict: opts.retryOnConflict,
+		ReturnSource:    opts.source,
 	}
 	bi.operations = append(bi.operations, op)
 	return bi
 }
 
-// UpdateWithScript adds an update operation with script to the bulk request
-func (bi *BulkIndexer) UpdateWithScript(id string, script map[string]any) *BulkIndexer {
+// UpdateWithScript adds an update operation with script to the bulk request; use
+// WithRetryOnConflict for scripts that update contended documents
+func (bi *BulkIndexer) UpdateWithScript(id string, script map[string]any, options ...UpdateOption) *BulkIndexer {
+	opts := buildUpdateOptions(options)
 	op := &BulkOperation{
-		Action: "update",
-		Index:  bi.index,
-		ID:     id,
-		Script: script,
+		Action:          "update",
+		Index:           bi.index,
+		ID:              id,
+		Script:          script,
+		RetryOnConflict: opts.retryOnConflict,
+		ReturnSource:    opts.source,
 	}
 	bi.operations = append(bi.operations, op)
 	return bi
@@ -99,6 +116,191 @@ func (bi *BulkIndexer) Do(ctx context.Context) (*BulkResponse, error) {
 	return bulkResource.Execute(ctx, bi.operations)
 }
 
+// DoWithRetry executes the bulk request and re-submits only the operations that failed
+// with a retryable status (429, 503), waiting backoff(attempt) between attempts.
+// If backoff is nil an exponential backoff starting at 100ms is used.
+// A request rejected as a whole with 429 (see BulkRequestError.Retryable) is resent within the same
+// maxRetries budget. Connection errors and 5xx statuses are left to the transport's own retries
+// (Config.MaxRetries, RetryOnStatus) and returned as is, since the request may already have been
+// applied. 429 is in the transport's default RetryOnStatus as well, so each attempt here may send
+// the request up to MaxRetries+1 times.
+// An operation is not retried once a later operation on the same document has succeeded, since
+// re-applying it would overwrite the newer write; it is reported with its original failure.
+// The returned response holds the final outcome of every operation in the original order,
+// so items that failed for non-retryable reasons (e.g. mapping errors) are available via FailedItems().
+func (bi *BulkIndexer) DoWithRetry(ctx context.Context, maxRetries int, backoff func(attempt int) time.Duration) (*BulkResponse, error) {
+	if backoff == nil {
+		backoff = defaultBulkRetryBackoff
+	}
+
+	bulkResource := &BulkResource{
+		client: bi.client,
+		index:  bi.index,
+	}
+
+	attempt := 0
+	response, err := bulkResource.Execute(ctx, bi.operations)
+	for err != nil {
+		if !isRetryableBulkRequest(err) || attempt >= maxRetries {
+			return nil, err
+		}
+		attempt++
+
+		bi.client.config.Logger.Warn("Retrying failed bulk request - attempt: %d, operations: %d, error: %s", attempt, len(bi.operations), err.Error())
+
+		if sleepErr := sleepWithContext(ctx, backoff(attempt)); sleepErr != nil {
+			return nil, sleepErr
+		}
+		response, err = bulkResource.Execute(ctx, bi.operations)
+	}
+
+	pending := retryableBulkPositions(response.Items)
+
+	for attempt++; attempt <= maxRetries; attempt++ {
+		pending = bi.orderSafeRetries(response.Items, pending)
+		if len(pending) == 0 {
+			break
+		}
+
+		if err := sleepWithContext(ctx, backoff(attempt)); err != nil {
+			return response, err
+		}
+
+		operations := make([]*BulkOperation, len(pending))
+		for i, pos := range pending {
+			operations[i] = bi.operations[pos]
+		}
+
+		bi.client.config.Logger.Warn("Retrying failed bulk items - attempt: %d, items: %d", attempt, len(operations))
+
+		retryResponse, err := bulkResource.Execute(ctx, operations)
+		if err != nil {
+			if isRetryableBulkRequest(err) && attempt < maxRetries {
+				// The items keep their previous outcome and are resent on the next attempt
+				bi.client.config.Logger.Warn("Bulk retry request failed - attempt: %d, error: %s", attempt, err.Error())
+				continue
+			}
+			return response, fmt.Errorf("bulk retry attempt %d failed: %w", attempt, err)
+		}
+		if len(retryResponse.Items) != len(pending) {
+			return response, fmt.Errorf("bulk retry attempt %d returned %d items, expected %d", attempt, len(retryResponse.Items), len(pending))
+		}
+
+		// Merge the retried outcomes back into their original positions
+		for i, pos := range pending {
+			response.Items[pos] = retryResponse.Items[i]
+		}
+		response.Took += retryResponse.Took
+
+		next := make([]int, 0, len(pending))
+		for i, pos := range pending {
+			if isRetryableBulkItem(retryResponse.Items[i]) {
+				next = append(next, pos)
+			}
+		}
+		pending = next
+	}
+
+	response.Errors = len(response.FailedItems()) > 0
+
+	return response, nil
+}
+
+// orderSafeRetries drops the pending positions whose document was written successfully by a later
+// operation of the request, so retries never apply writes to a document out of submission order
+func (bi *BulkIndexer) orderSafeRetries(items []map[string]any, pending []int) []int {
+	safe := make([]int, 0, len(pending))
+	for _, pos := range pending {
+		op := bi.operations[pos]
+		superseded := false
+		if op.ID != "" {
+			for later := pos + 1; later < len(bi.operations) && later < len(items); later++ {
+				next := bi.operations[later]
+				if next.Index == op.Index && next.ID == op.ID && next.Routing == op.Routing && !bulkItemFailed(items[later]) {
+					superseded = true
+					break
+				}
+			}
+		}
+		if superseded {
+			bi.client.config.Logger.Warn("Not retrying bulk item superseded by a later write - position: %d, index: %s, id: %s", pos, op.Index, op.ID)
+			continue
+		}
+		safe = append(safe, pos)
+	}
+	return safe
+}
+
+// bulkItemFailed reports whether a bulk response item holds a failure
+func bulkItemFailed(item map[string]any) bool {
+	for action, raw := range item {
+		if parseBulkItem(action, raw).Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultBulkRetryBackoff doubles the delay on every attempt, starting at 100ms and capped at 30s
+func defaultBulkRetryBackoff(attempt int) time.Duration {
+	const maxDelay = 30 * time.Second
+
+	delay := 100 * time.Millisecond
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+// retryableBulkPositions returns the positions of the items that failed with a retryable status
+func retryableBulkPositions(items []map[string]any) []int {
+	var positions []int
+	for i, item := range items {
+		if isRetryableBulkItem(item) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// isRetryableBulkItem reports whether a bulk item failed with a status worth retrying
+func isRetryableBulkItem(item map[string]any) bool {
+	for action, raw := range item {
+		result := parseBulkItem(action, raw)
+		if result.Status == 429 || result.Status == 503 {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryableBulkRequest reports whether a failed bulk request may succeed when resent
+func isRetryableBulkRequest(err error) bool {
+	var requestErr *BulkRequestError
+	return errors.As(err, &requestErr) && requestErr.Retryable()
+}
+
+// sleepWithContext waits for the given duration or until the context is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // Legacy methods for backward compatibility
 
 // BulkRaw performs bulk operations using raw operation maps
@@ -109,6 +311,53 @@ func (s *DocumentsService) BulkRaw(ctx context.Context, operations []map[string]
 	return bulkResource.ExecuteRaw(ctx, operations)
 }
 
+// DeleteMany deletes documents by ID with a single bulk request. IDs that don't exist are reported
+// with the "not_found" result; use a BulkProcessor to delete very large sets in batches.
+func (s *DocumentsService) DeleteMany(ctx context.Context, indexName string, documentIDs []string) (*BulkResponse, error) {
+	if len(documentIDs) == 0 {
+		return &BulkResponse{}, nil
+	}
+
+	bulkResource := &BulkResource{
+		client: s.client,
+		index:  indexName,
+	}
+
+	operations := make([]*BulkOperation, len(documentIDs))
+	for i, id := range documentIDs {
+		operations[i] = bulkResource.Delete(indexName, id)
+	}
+
+	return bulkResource.Execute(ctx, operations)
+}
+
+// UpdateMany applies a different partial document to each document, keyed by ID, with a single
+// bulk request. Each patch is merged like Update; use UpdateByQuery to apply one script to all matches.
+func (s *DocumentsService) UpdateMany(ctx context.Context, indexName string, updates map[string]map[string]any) (*BulkResponse, error) {
+	if len(updates) == 0 {
+		return &BulkResponse{}, nil
+	}
+
+	bulkResource := &BulkResource{
+		client: s.client,
+		index:  indexName,
+	}
+
+	// Sort IDs so the request (and the response items) have a stable order
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	operations := make([]*BulkOperation, len(ids))
+	for i, id := range ids {
+		operations[i] = bulkResource.Update(indexName, id, updates[id])
+	}
+
+	return bulkResource.Execute(ctx, operations)
+}
+
 // ForIndex returns a BulkResource configured for a specific index
 func (s *DocumentsService) ForIndex(indexName string) *BulkResource {
 	return &BulkResource{
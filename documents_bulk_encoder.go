@@ -0,0 +1,314 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// maxPooledBufferSize keeps unusually large request bodies from being retained by the buffer pool
+const maxPooledBufferSize = 8 << 20
+
+// bufferPool reuses request body buffers, so sustained bulk ingestion doesn't allocate a new body per request
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to the pool once the request that used it has completed
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// pooledBody shares a pooled buffer between a request body and the copies GetBody hands out when
+// the transport resends it, so retries don't copy the body. The transport may read a body after
+// RoundTrip returns, so the buffer goes back to the pool only once the sender released it and every
+// reader was read to the end or closed. A reader that is never closed only keeps the buffer from
+// being reused.
+type pooledBody struct {
+	mutex sync.Mutex
+	buf   *bytes.Buffer
+	refs  int
+}
+
+// newPooledBody wraps a buffer from getBuffer; the caller owns one reference and must call release
+// once the request has been sent
+func newPooledBody(buf *bytes.Buffer) *pooledBody {
+	return &pooledBody{
+		buf:  buf,
+		refs: 1,
+	}
+}
+
+// reader returns a new reader over the buffered bytes that holds a reference until it is done
+func (b *pooledBody) reader() io.ReadCloser {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refs++
+	return &pooledBodyReader{
+		body:   b,
+		reader: bytes.NewReader(b.buf.Bytes()),
+	}
+}
+
+// getBody implements http.Request.GetBody
+func (b *pooledBody) getBody() (io.ReadCloser, error) {
+	return b.reader(), nil
+}
+
+// release drops a reference, returning the buffer to the pool with the last one
+func (b *pooledBody) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refs--
+	if b.refs == 0 {
+		putBuffer(b.buf)
+		b.buf = nil
+	}
+}
+
+// transport wraps next so the request it sends gets a GetBody over the pooled bytes. Without one,
+// a transport with retries enabled copies the whole body before sending it.
+func (b *pooledBody) transport(next esapi.Transport) esapi.Transport {
+	return &pooledBodyTransport{
+		next: next,
+		body: b,
+	}
+}
+
+// pooledBodyReader reads a pooledBody, releasing its reference at EOF or on Close
+type pooledBodyReader struct {
+	mutex  sync.Mutex
+	body   *pooledBody
+	reader *bytes.Reader
+}
+
+// Read implements io.Reader
+func (r *pooledBodyReader) Read(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.reader == nil {
+		return 0, io.EOF
+	}
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		r.done()
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (r *pooledBodyReader) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.done()
+	return nil
+}
+
+// done releases the reader's reference once; the caller holds the mutex
+func (r *pooledBodyReader) done() {
+	if r.reader == nil {
+		return
+	}
+	r.reader = nil
+	r.body.release()
+}
+
+// pooledBodyTransport sets GetBody and the content length of requests carrying a pooledBody
+type pooledBodyTransport struct {
+	next esapi.Transport
+	body *pooledBody
+}
+
+// Perform implements esapi.Transport
+func (t *pooledBodyTransport) Perform(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		req.GetBody = t.body.getBody
+		req.ContentLength = int64(t.body.buf.Len())
+	}
+	return t.next.Perform(req)
+}
+
+// encodeJSON writes v to buf with codec. The default JSONCodec encodes straight into the buffer
+// instead of allocating an intermediate byte slice.
+func encodeJSON(buf *bytes.Buffer, codec Codec, v any) error {
+	if _, ok := codec.(JSONCodec); ok {
+		return json.NewEncoder(buf).Encode(v)
+	}
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// newline terminates every NDJSON line of a bulk body
+var newline = []byte{'\n'}
+
+// bulkActionMeta is the metadata of a bulk action line
+type bulkActionMeta struct {
+	ID              string `json:"_id,omitempty"`
+	Index           string `json:"_index"`
+	Routing         string `json:"routing,omitempty"`
+	RetryOnConflict int    `json:"retry_on_conflict,omitempty"`
+	RequireAlias    bool   `json:"require_alias,omitempty"`
+}
+
+// bulkEncoder streams bulk operations to a writer as NDJSON, one operation at a time
+type bulkEncoder struct {
+	client *Client
+	codec  Codec
+	w      io.Writer
+}
+
+// writeBody writes the NDJSON bulk request body for the given operations to w
+func (br *BulkResource) writeBody(w io.Writer, operations []*BulkOperation) error {
+	encoder := &bulkEncoder{
+		client: br.client,
+		codec:  br.client.codec(),
+		w:      w,
+	}
+	for i, op := range operations {
+		if err := encoder.encode(i, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode writes the action line and, except for deletes, the source line of one operation
+func (e *bulkEncoder) encode(i int, op *BulkOperation) error {
+	// Enhance index/create documents first, so an ID generated by the ID mode
+	// can be promoted to the action line
+	var enhanced map[string]any
+	documentID := op.ID
+	if (op.Action == "index" || op.Action == "create") && op.Document != nil {
+		if err := e.client.validateDocument(op.Document); err != nil {
+			return fmt.Errorf("bulk operation %d (%s %s): %w", i, op.Action, op.Index, err)
+		}
+		enhanced = e.client.enhanceDocument(op.Document)
+		generatedID, err := takeDocumentID(enhanced)
+		if err != nil {
+			return fmt.Errorf("bulk operation %d (%s %s): %w", i, op.Action, op.Index, err)
+		}
+		if documentID == "" {
+			// Pin the generated ID on the operation, so a resent operation keeps it
+			// and can't create a duplicate document
+			documentID = generatedID
+			op.ID = generatedID
+		}
+	}
+
+	if op.RetryOnConflict < 0 {
+		return fmt.Errorf("bulk operation %d (%s %s): retry_on_conflict cannot be negative, got %d", i, op.Action, op.Index, op.RetryOnConflict)
+	}
+	if op.RetryOnConflict > 0 && op.Action != "update" {
+		return fmt.Errorf("bulk operation %d (%s %s): retry_on_conflict only applies to updates", i, op.Action, op.Index)
+	}
+	if op.RequireAlias && op.Action != "index" && op.Action != "create" {
+		return fmt.Errorf("bulk operation %d (%s %s): require_alias only applies to index and create", i, op.Action, op.Index)
+	}
+
+	// Action line
+	meta, err := e.codec.Marshal(bulkActionMeta{
+		Index:           op.Index,
+		ID:              documentID,
+		Routing:         op.Routing,
+		RetryOnConflict: op.RetryOnConflict,
+		RequireAlias:    op.RequireAlias,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal action line: %w", err)
+	}
+	if err := e.writeAction(op.Action, meta); err != nil {
+		return err
+	}
+
+	// Document line (if needed)
+	switch op.Action {
+	case "index", "create":
+		if enhanced != nil {
+			docBytes, err := e.codec.Marshal(enhanced)
+			if err != nil {
+				return fmt.Errorf("failed to marshal document: %w", err)
+			}
+			return e.writeLine(docBytes)
+		}
+	case "update":
+		updateDoc := make(map[string]any)
+		if op.Document != nil {
+			// Partial document merge, same as a single-document Update
+			partial, err := e.client.buildPartialUpdate(op.Document)
+			if err != nil {
+				return fmt.Errorf("bulk operation %d (%s %s): %w", i, op.Action, op.Index, err)
+			}
+			updateDoc["doc"] = partial["doc"]
+		} else if op.UpsertDoc != nil {
+			updateDoc["doc"] = op.UpsertDoc
+			updateDoc["doc_as_upsert"] = true
+		}
+		if op.Script != nil {
+			updateDoc["script"] = op.Script
+		}
+		if op.ReturnSource {
+			updateDoc["_source"] = true
+		}
+
+		docBytes, err := e.codec.Marshal(updateDoc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal update document: %w", err)
+		}
+		return e.writeLine(docBytes)
+	}
+	// Delete operations only need the action line
+
+	return nil
+}
+
+// writeAction writes an action line such as {"index":{"_id":"1","_index":"products"}}
+func (e *bulkEncoder) writeAction(action string, meta []byte) error {
+	_, err := io.WriteString(e.w, `{"`+action+`":`)
+	if err == nil {
+		_, err = e.w.Write(meta)
+	}
+	if err == nil {
+		_, err = io.WriteString(e.w, "}\n")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write action line: %w", err)
+	}
+	return nil
+}
+
+// writeLine writes data followed by a newline
+func (e *bulkEncoder) writeLine(data []byte) error {
+	if _, err := e.w.Write(data); err != nil {
+		return fmt.Errorf("failed to write bulk body: %w", err)
+	}
+	if _, err := e.w.Write(newline); err != nil {
+		return fmt.Errorf("failed to write bulk body: %w", err)
+	}
+	return nil
+}
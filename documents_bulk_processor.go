@@ -0,0 +1,246 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBulkProcessorClosed is returned when operations are added to a closed BulkProcessor
+var ErrBulkProcessorClosed = errors.New("bulk processor is closed")
+
+// ErrBulkProcessorFull is returned when an operation is added while MaxPending operations are
+// queued or being sent; the operation is not queued
+var ErrBulkProcessorFull = errors.New("bulk processor is full")
+
+// ErrBulkBatchDropped is returned by Flush when a batch failed in a way that resending can't fix,
+// such as an invalid document or a 400 for the whole request; its operations are not retried
+var ErrBulkBatchDropped = errors.New("bulk batch dropped")
+
+// bulkProcessorDrainRetryDelay is the pause between failed flushes while a BulkProcessor drains
+const bulkProcessorDrainRetryDelay = 500 * time.Millisecond
+
+// BulkProcessorConfig holds configuration for a BulkProcessor
+type BulkProcessorConfig struct {
+	BatchSize     int                        // Number of queued operations that triggers a flush (default: 1000)
+	MaxPending    int                        // Queued plus in-flight operations at which Add fails with ErrBulkProcessorFull (default: 10 * BatchSize)
+	FlushInterval time.Duration              // Periodic flush interval (0 = only flush on BatchSize or Close)
+	OnFlush       func(*BulkResponse, error) // Optional callback invoked after every flush
+}
+
+// BulkProcessor queues bulk operations and sends them in batches.
+// It implements Shutdownable and ContextShutdownable: Close drains all queued operations before
+// returning, retrying requeued batches until the drain deadline.
+type BulkProcessor struct {
+	client  *Client
+	index   string
+	config  BulkProcessorConfig
+	execute func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error)
+
+	mutex      sync.Mutex
+	flushMutex sync.Mutex
+	pending    []*BulkOperation
+	inFlight   int
+	closed     bool
+	stop       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// BulkProcessor creates a BulkProcessor that writes to the specified index
+func (s *DocumentsService) BulkProcessor(indexName string, config BulkProcessorConfig) *BulkProcessor {
+	bulkResource := &BulkResource{
+		client: s.client,
+		index:  indexName,
+	}
+	return newBulkProcessor(s.client, indexName, config, bulkResource.Execute)
+}
+
+// newBulkProcessor creates a BulkProcessor using the given execute function
+func newBulkProcessor(client *Client, indexName string, config BulkProcessorConfig, execute func(context.Context, []*BulkOperation) (*BulkResponse, error)) *BulkProcessor {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = 10 * config.BatchSize
+	}
+
+	bp := &BulkProcessor{
+		client:  client,
+		index:   indexName,
+		config:  config,
+		execute: execute,
+		pending: make([]*BulkOperation, 0, config.BatchSize),
+		stop:    make(chan struct{}),
+	}
+
+	if config.FlushInterval > 0 {
+		bp.wg.Add(1)
+		go bp.flushPeriodically()
+	}
+
+	return bp
+}
+
+// Add queues a bulk operation, flushing when the batch size is reached. It only fails when the
+// operation was not queued; flush failures are reported through OnFlush and Flush, so a caller
+// never queues the same operation twice.
+func (bp *BulkProcessor) Add(op *BulkOperation) error {
+	if op.Index == "" {
+		op.Index = bp.index
+	}
+
+	bp.mutex.Lock()
+	if bp.closed {
+		bp.mutex.Unlock()
+		return ErrBulkProcessorClosed
+	}
+	if len(bp.pending)+bp.inFlight >= bp.config.MaxPending {
+		bp.mutex.Unlock()
+		return ErrBulkProcessorFull
+	}
+	bp.pending = append(bp.pending, op)
+	full := len(bp.pending) >= bp.config.BatchSize
+	bp.mutex.Unlock()
+
+	if full {
+		_ = bp.Flush(nil) // Logged by Flush and passed to OnFlush
+	}
+	return nil
+}
+
+// Index queues an index operation
+func (bp *BulkProcessor) Index(id string, document any) error {
+	return bp.Add(&BulkOperation{
+		Action:   "index",
+		ID:       id,
+		Document: document,
+	})
+}
+
+// Delete queues a delete operation
+func (bp *BulkProcessor) Delete(id string) error {
+	return bp.Add(&BulkOperation{
+		Action: "delete",
+		ID:     id,
+	})
+}
+
+// Pending returns the number of queued operations that have not been sent yet
+func (bp *BulkProcessor) Pending() int {
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+	return len(bp.pending)
+}
+
+// Flush sends all queued operations immediately. When the bulk request is rejected with 429 or
+// the context ends first, the batch is put back in front of the queue so the next flush retries it.
+// Any other failure drops the batch and returns an error wrapping ErrBulkBatchDropped, so one bad
+// operation can't block the operations queued behind it.
+func (bp *BulkProcessor) Flush(ctx context.Context) error {
+	// Serialise flushes so batches are sent in the order they were queued
+	bp.flushMutex.Lock()
+	defer bp.flushMutex.Unlock()
+
+	bp.mutex.Lock()
+	if len(bp.pending) == 0 {
+		bp.mutex.Unlock()
+		return nil
+	}
+	batch := bp.pending
+	bp.pending = make([]*BulkOperation, 0, bp.config.BatchSize)
+	bp.inFlight = len(batch)
+	bp.mutex.Unlock()
+
+	response, err := bp.execute(ctx, batch)
+
+	bp.mutex.Lock()
+	bp.inFlight = 0
+	if err != nil {
+		if requeueableBulkFlush(err) {
+			bp.client.config.Logger.Error("Bulk processor flush failed, operations requeued - operations: %d, error: %s", len(batch), err.Error())
+			bp.pending = append(batch, bp.pending...)
+		} else {
+			bp.client.config.Logger.Error("Bulk processor flush failed, operations dropped - operations: %d, error: %s", len(batch), err.Error())
+			err = fmt.Errorf("%w: %d operations: %w", ErrBulkBatchDropped, len(batch), err)
+		}
+	}
+	bp.mutex.Unlock()
+
+	if bp.config.OnFlush != nil {
+		bp.config.OnFlush(response, err)
+	}
+
+	return err
+}
+
+// requeueableBulkFlush reports whether a failed batch should be sent again: Elasticsearch rejected
+// it with 429, or the context ended, which says nothing about the batch itself
+func requeueableBulkFlush(err error) bool {
+	return isRetryableBulkRequest(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Close stops the periodic flush and drains all queued operations, retrying requeued batches for
+// up to 30 seconds. Use CloseContext to drain within a different deadline.
+func (bp *BulkProcessor) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return bp.CloseContext(ctx)
+}
+
+// CloseContext stops the periodic flush and drains all queued operations, retrying requeued batches
+// until ctx is done. It returns an error naming the operations left unsent, or else the first
+// dropped batch.
+func (bp *BulkProcessor) CloseContext(ctx context.Context) error {
+	bp.mutex.Lock()
+	if !bp.closed {
+		bp.closed = true
+		close(bp.stop)
+	}
+	bp.mutex.Unlock()
+
+	bp.wg.Wait()
+
+	bp.client.config.Logger.Info("Draining bulk processor - index: %s, pending: %d", bp.index, bp.Pending())
+
+	var dropErr error
+	for {
+		err := bp.Flush(ctx)
+		if err == nil && bp.Pending() == 0 {
+			return dropErr
+		}
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrBulkBatchDropped) {
+			if dropErr == nil {
+				dropErr = err
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("bulk processor drain stopped with %d operations unsent: %w", bp.Pending(), err)
+		case <-time.After(bulkProcessorDrainRetryDelay):
+		}
+	}
+}
+
+// flushPeriodically flushes queued operations every FlushInterval until the processor is closed
+func (bp *BulkProcessor) flushPeriodically() {
+	defer bp.wg.Done()
+
+	ticker := time.NewTicker(bp.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bp.stop:
+			return
+		case <-ticker.C:
+			_ = bp.Flush(nil)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBulkProcessorFlushesOnBatchSize(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	var batches [][]*BulkOperation
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{BatchSize: 2},
+		func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+			batches = append(batches, operations)
+			return &BulkResponse{}, nil
+		})
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := processor.Index(id, map[string]any{"id": id}); err != nil {
+			t.Fatalf("Failed to add operation: %v", err)
+		}
+	}
+
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("Expected one batch of 2 operations, got %v", batches)
+	}
+	if processor.Pending() != 1 {
+		t.Errorf("Expected 1 pending operation, got %d", processor.Pending())
+	}
+
+	if err := processor.Close(); err != nil {
+		t.Fatalf("Failed to close processor: %v", err)
+	}
+	if len(batches) != 2 || batches[1][0].ID != "3" {
+		t.Errorf("Expected Close to drain the remaining operation, got %v", batches)
+	}
+}
+
+func TestBulkProcessorRequeuesFailedFlush(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	attempts := 0
+	var sent []*BulkOperation
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{BatchSize: 100},
+		func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, &BulkRequestError{StatusCode: 429, Status: "429 Too Many Requests"}
+			}
+			sent = append(sent, operations...)
+			return &BulkResponse{}, nil
+		})
+
+	for _, id := range []string{"1", "2"} {
+		if err := processor.Index(id, map[string]any{"id": id}); err != nil {
+			t.Fatalf("Failed to add operation: %v", err)
+		}
+	}
+
+	if err := processor.Flush(context.Background()); err == nil {
+		t.Fatal("Expected the first flush to fail")
+	}
+	if processor.Pending() != 2 {
+		t.Fatalf("Expected the failed batch to be requeued, got %d pending", processor.Pending())
+	}
+	if err := processor.Index("3", map[string]any{"id": "3"}); err != nil {
+		t.Fatalf("Failed to add operation: %v", err)
+	}
+
+	if err := processor.Close(); err != nil {
+		t.Fatalf("Expected Close to drain after the transient failure, got %v", err)
+	}
+	if len(sent) != 3 || sent[0].ID != "1" || sent[1].ID != "2" || sent[2].ID != "3" {
+		t.Errorf("Expected operations 1, 2, 3 in order, got %v", sent)
+	}
+}
+
+func TestBulkProcessorDropsBatchThatCannotSucceed(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	var sent []*BulkOperation
+	var flushErrors []error
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{
+		BatchSize: 100,
+		OnFlush:   func(_ *BulkResponse, err error) { flushErrors = append(flushErrors, err) },
+	}, func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+		if operations[0].ID == "bad" {
+			return nil, &BulkRequestError{StatusCode: 400, Status: "400 Bad Request"}
+		}
+		sent = append(sent, operations...)
+		return &BulkResponse{}, nil
+	})
+
+	if err := processor.Index("bad", map[string]any{"id": "bad"}); err != nil {
+		t.Fatalf("Failed to add operation: %v", err)
+	}
+	err := processor.Flush(context.Background())
+	if !errors.Is(err, ErrBulkBatchDropped) || !errors.Is(err, ErrBulkRequestFailed) {
+		t.Fatalf("Expected the batch to be dropped with the request error, got %v", err)
+	}
+	if processor.Pending() != 0 {
+		t.Fatalf("Expected the dropped batch not to be requeued, got %d pending", processor.Pending())
+	}
+	if len(flushErrors) != 1 || !errors.Is(flushErrors[0], ErrBulkBatchDropped) {
+		t.Errorf("Expected OnFlush to report the dropped batch, got %v", flushErrors)
+	}
+
+	if err := processor.Index("1", map[string]any{"id": "1"}); err != nil {
+		t.Fatalf("Failed to add operation: %v", err)
+	}
+	if err := processor.Close(); err != nil {
+		t.Fatalf("Expected Close to drain the operations behind the dropped batch, got %v", err)
+	}
+	if len(sent) != 1 || sent[0].ID != "1" {
+		t.Errorf("Expected operation 1 to be sent, got %v", sent)
+	}
+}
+
+func TestBulkProcessorAddReturnsNilOnceQueued(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	var flushErr error
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{
+		BatchSize: 1,
+		OnFlush:   func(_ *BulkResponse, err error) { flushErr = err },
+	}, func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+		return nil, &BulkRequestError{StatusCode: 429, Status: "429 Too Many Requests"}
+	})
+
+	if err := processor.Index("1", map[string]any{"id": "1"}); err != nil {
+		t.Errorf("Expected Add to succeed once the operation is queued, got %v", err)
+	}
+	if flushErr == nil {
+		t.Error("Expected the flush failure to be reported through OnFlush")
+	}
+	if processor.Pending() != 1 {
+		t.Errorf("Expected the operation to be queued once, got %d pending", processor.Pending())
+	}
+}
+
+func TestBulkProcessorMaxPending(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{BatchSize: 100, MaxPending: 2},
+		func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+			return nil, &BulkRequestError{StatusCode: 429, Status: "429 Too Many Requests"}
+		})
+
+	for _, id := range []string{"1", "2"} {
+		if err := processor.Index(id, map[string]any{"id": id}); err != nil {
+			t.Fatalf("Failed to add operation: %v", err)
+		}
+	}
+	if err := processor.Index("3", map[string]any{"id": "3"}); !errors.Is(err, ErrBulkProcessorFull) {
+		t.Errorf("Expected ErrBulkProcessorFull past MaxPending, got %v", err)
+	}
+
+	_ = processor.Flush(context.Background())
+	if err := processor.Index("3", map[string]any{"id": "3"}); !errors.Is(err, ErrBulkProcessorFull) {
+		t.Errorf("Expected requeued operations to count against MaxPending, got %v", err)
+	}
+	if processor.Pending() != 2 {
+		t.Errorf("Expected 2 pending operations, got %d", processor.Pending())
+	}
+}
+
+func TestBulkProcessorCloseContextReportsUnsent(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+
+	processor := newBulkProcessor(client, "logs", BulkProcessorConfig{BatchSize: 100},
+		func(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+			return nil, &BulkRequestError{StatusCode: 429, Status: "429 Too Many Requests"}
+		})
+	if err := processor.Index("1", map[string]any{"id": "1"}); err != nil {
+		t.Fatalf("Failed to add operation: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := processor.CloseContext(ctx)
+	if err == nil || !strings.Contains(err.Error(), "1 operations unsent") {
+		t.Errorf("Expected an error naming the unsent operation, got %v", err)
+	}
+	if processor.Pending() != 1 {
+		t.Errorf("Expected the operation to stay queued, got %d pending", processor.Pending())
+	}
+}
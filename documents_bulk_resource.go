@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v9/esapi"
@@ -21,66 +20,89 @@ type BulkResource struct {
 type BulkOperation struct {
 	Action    string         `json:"action"`   // index, create, update, delete
 	Index     string         `json:"index"`    // target index
-	ID        string         `json:"id"`       // document ID
+	ID        string         `json:"id"`       // document ID (set to the generated ID once encoded in ULID mode)
 	Document  any            `json:"document"` // document data (can be any type)
 	Source    map[string]any `json:"_source"`  // for updates
 	Script    map[string]any `json:"script"`   // for script updates
 	UpsertDoc map[string]any `json:"doc"`      // for upserts
+
+	// Routing sends the operation to the shard of the given routing value instead of the one derived from the ID
+	Routing string `json:"routing"`
+
+	// RetryOnConflict retries an update this many times when the document changed between its
+	// get and index phases, which scripted updates of contended documents (counters) need
+	RetryOnConflict int `json:"retry_on_conflict"`
+
+	// ReturnSource requests the document source for updates: the updated document on success, or
+	// the current document (fetched after the bulk request) when the update hit a version conflict
+	ReturnSource bool `json:"return_source"`
+
+	// RequireAlias fails an index or create operation unless Index names an alias
+	RequireAlias bool `json:"require_alias"`
 }
 
-// Index adds an index operation to the bulk request
-func (br *BulkResource) Index(indexName, documentID string, document any) *BulkOperation {
+// Index adds an index operation to the bulk request. WithRequireAlias(true) sets RequireAlias.
+func (br *BulkResource) Index(indexName, documentID string, document any, options ...WriteOption) *BulkOperation {
 	if indexName == "" && br.index != "" {
 		indexName = br.index
 	}
 
 	return &BulkOperation{
-		Action:   "index",
-		Index:    indexName,
-		ID:       documentID,
-		Document: document,
+		Action:       "index",
+		Index:        indexName,
+		ID:           documentID,
+		Document:     document,
+		RequireAlias: buildWriteOptions(options).requireAlias,
 	}
 }
 
-// Create adds a create operation to the bulk request
-func (br *BulkResource) Create(indexName, documentID string, document any) *BulkOperation {
+// Create adds a create operation to the bulk request. WithRequireAlias(true) sets RequireAlias.
+func (br *BulkResource) Create(indexName, documentID string, document any, options ...WriteOption) *BulkOperation {
 	if indexName == "" && br.index != "" {
 		indexName = br.index
 	}
 
 	return &BulkOperation{
-		Action:   "create",
-		Index:    indexName,
-		ID:       documentID,
-		Document: document,
+		Action:       "create",
+		Index:        indexName,
+		ID:           documentID,
+		Document:     document,
+		RequireAlias: buildWriteOptions(options).requireAlias,
 	}
 }
 
-// Update adds an update operation to the bulk request
-func (br *BulkResource) Update(indexName, documentID string, doc any) *BulkOperation {
+// Update adds an update operation to the bulk request. WithUpdateSource(true) sets ReturnSource.
+func (br *BulkResource) Update(indexName, documentID string, doc any, options ...UpdateOption) *BulkOperation {
 	if indexName == "" && br.index != "" {
 		indexName = br.index
 	}
 
+	opts := buildUpdateOptions(options)
 	return &BulkOperation{
-		Action:   "update",
-		Index:    indexName,
-		ID:       documentID,
-		Document: doc,
+		Action:          "update",
+		Index:           indexName,
+		ID:              documentID,
+		Document:        doc,
+		RetryOnConflict: opts.retryOnConflict,
+		ReturnSource:    opts.source,
 	}
 }
 
-// UpdateWithScript adds an update operation with script to the bulk request
-func (br *BulkResource) UpdateWithScript(indexName, documentID string, script map[string]any) *BulkOperation {
+// UpdateWithScript adds an update operation with script to the bulk request. WithRetryOnConflict
+// sets RetryOnConflict; a negative value is rejected when the request body is built.
+func (br *BulkResource) UpdateWithScript(indexName, documentID string, script map[string]any, options ...UpdateOption) *BulkOperation {
 	if indexName == "" && br.index != "" {
 		indexName = br.index
 	}
 
+	opts := buildUpdateOptions(options)
 	return &BulkOperation{
-		Action: "update",
-		Index:  indexName,
-		ID:     documentID,
-		Script: script,
+		Action:          "update",
+		Index:           indexName,
+		ID:              documentID,
+		Script:          script,
+		RetryOnConflict: opts.retryOnConflict,
+		ReturnSource:    opts.source,
 	}
 }
 
@@ -97,8 +119,14 @@ func (br *BulkResource) Delete(indexName, documentID string) *BulkOperation {
 	}
 }
 
-// Execute performs a bulk operation with the given operations
+// Execute performs a bulk operation with the given operations. Operations are written to the
+// request in slice order, and Elasticsearch applies operations on the same document (same index,
+// ID and routing) in that order, so a sequence of writes to one document ends with the last one.
 func (br *BulkResource) Execute(ctx context.Context, operations []*BulkOperation) (*BulkResponse, error) {
+	if err := br.client.checkWritable("bulk"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -109,68 +137,24 @@ func (br *BulkResource) Execute(ctx context.Context, operations []*BulkOperation
 		return nil, fmt.Errorf("no operations provided")
 	}
 
-	// Build bulk request body
-	var body strings.Builder
-	for _, op := range operations {
-		// Action line
-		actionLine := map[string]map[string]any{
-			op.Action: {
-				"_index": op.Index,
-			},
-		}
-
-		if op.ID != "" {
-			actionLine[op.Action]["_id"] = op.ID
-		}
-
-		actionBytes, err := json.Marshal(actionLine)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal action line: %w", err)
-		}
-		body.Write(actionBytes)
-		body.WriteString("\n")
-
-		// Document line (if needed)
-		switch op.Action {
-		case "index", "create":
-			if op.Document != nil {
-				// Enhance document with metadata
-				enhanced := br.client.enhanceDocument(op.Document)
-				docBytes, err := json.Marshal(enhanced)
-				if err != nil {
-					return nil, fmt.Errorf("failed to marshal document: %w", err)
-				}
-				body.Write(docBytes)
-				body.WriteString("\n")
-			}
-		case "update":
-			updateDoc := make(map[string]any)
-			if op.UpsertDoc != nil {
-				updateDoc["doc"] = op.UpsertDoc
-				updateDoc["doc_as_upsert"] = true
-			}
-			if op.Script != nil {
-				updateDoc["script"] = op.Script
-			}
-
-			docBytes, err := json.Marshal(updateDoc)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal update document: %w", err)
-			}
-			body.Write(docBytes)
-			body.WriteString("\n")
-		}
-		// Delete operations only need the action line
+	body := getBuffer()
+	if err := br.writeBody(body, operations); err != nil {
+		putBuffer(body)
+		return nil, err
 	}
 
+	// The buffer goes back to the pool once the transport is done with every copy of the body
+	pooled := newPooledBody(body)
+	defer pooled.release()
+
 	req := esapi.BulkRequest{
-		Body: strings.NewReader(body.String()),
+		Body: pooled.reader(),
 	}
 
-	res, err := req.Do(ctx, br.client.client)
+	res, err := req.Do(ctx, pooled.transport(br.client.client))
 	if err != nil {
 		br.client.config.Logger.Error("Bulk operation failed - operations: %d, error: %s", len(operations), err.Error())
-		return nil, fmt.Errorf("bulk request failed: %w", err)
+		return nil, &BulkRequestError{Err: err}
 	}
 	defer func() {
 		if err := res.Body.Close(); err != nil {
@@ -181,7 +165,7 @@ func (br *BulkResource) Execute(ctx context.Context, operations []*BulkOperation
 	if res.IsError() {
 		bodyBytes, _ := io.ReadAll(res.Body)
 		br.client.config.Logger.Error("Bulk operation failed - operations: %d, status: %s, response: %s", len(operations), res.Status(), string(bodyBytes))
-		return nil, fmt.Errorf("bulk operation failed: %s - %s", res.Status(), string(bodyBytes))
+		return nil, &BulkRequestError{StatusCode: res.StatusCode, Status: res.Status(), Body: string(bodyBytes)}
 	}
 
 	var bulkResponse BulkResponse
@@ -189,6 +173,10 @@ func (br *BulkResource) Execute(ctx context.Context, operations []*BulkOperation
 		return nil, fmt.Errorf("failed to decode bulk response: %w", err)
 	}
 
+	if bulkResponse.Errors {
+		br.fetchConflictSources(ctx, operations, &bulkResponse)
+	}
+
 	br.client.config.Logger.Info("Bulk operation completed successfully - operations: %d, took: %d, errors: %t", len(operations), bulkResponse.Took, bulkResponse.Errors)
 
 	return &bulkResponse, nil
@@ -196,6 +184,10 @@ func (br *BulkResource) Execute(ctx context.Context, operations []*BulkOperation
 
 // ExecuteRaw performs a bulk operation with raw operations (legacy compatibility)
 func (br *BulkResource) ExecuteRaw(ctx context.Context, operations []map[string]any) (*BulkResponse, error) {
+	if err := br.client.checkWritable("bulk"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -207,23 +199,28 @@ func (br *BulkResource) ExecuteRaw(ctx context.Context, operations []map[string]
 	}
 
 	// Build bulk request body
-	var body strings.Builder
+	body := getBuffer()
+	codec := br.client.codec()
 	for _, op := range operations {
-		opBytes, err := json.Marshal(op)
+		opBytes, err := codec.Marshal(op)
 		if err != nil {
+			putBuffer(body)
 			return nil, fmt.Errorf("failed to marshal operation: %w", err)
 		}
 		body.Write(opBytes)
-		body.WriteString("\n")
+		body.WriteByte('\n')
 	}
 
+	pooled := newPooledBody(body)
+	defer pooled.release()
+
 	req := esapi.BulkRequest{
-		Body: strings.NewReader(body.String()),
+		Body: pooled.reader(),
 	}
 
-	res, err := req.Do(ctx, br.client.client)
+	res, err := req.Do(ctx, pooled.transport(br.client.client))
 	if err != nil {
-		return nil, fmt.Errorf("bulk request failed: %w", err)
+		return nil, &BulkRequestError{Err: err}
 	}
 	defer func() {
 		if err := res.Body.Close(); err != nil {
@@ -233,7 +230,7 @@ func (br *BulkResource) ExecuteRaw(ctx context.Context, operations []map[string]
 
 	if res.IsError() {
 		bodyBytes, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("bulk operation failed: %s - %s", res.Status(), string(bodyBytes))
+		return nil, &BulkRequestError{StatusCode: res.StatusCode, Status: res.Status(), Body: string(bodyBytes)}
 	}
 
 	var bulkResponse BulkResponse
@@ -243,3 +240,50 @@ func (br *BulkResource) ExecuteRaw(ctx context.Context, operations []map[string]
 
 	return &bulkResponse, nil
 }
+
+// fetchConflictSources fetches the current source of updates that requested it and failed with a
+// version conflict, and adds it to their response items so BulkItemResult.Source carries it.
+// A failed fetch is logged rather than returned, since the bulk outcome itself is still valid.
+func (br *BulkResource) fetchConflictSources(ctx context.Context, operations []*BulkOperation, response *BulkResponse) {
+	if len(response.Items) != len(operations) {
+		return
+	}
+
+	var positions []int
+	var refs []DocRef
+	for i, op := range operations {
+		if op.Action != "update" || !op.ReturnSource {
+			continue
+		}
+		fields, ok := response.Items[i][op.Action].(map[string]any)
+		if !ok || parseBulkItem(op.Action, fields).Status != 409 {
+			continue
+		}
+		positions = append(positions, i)
+		refs = append(refs, DocRef{Index: op.Index, ID: op.ID, Routing: op.Routing})
+	}
+	if len(refs) == 0 {
+		return
+	}
+
+	doc := &Document{
+		client: br.client,
+	}
+	docs, err := doc.GetRefs(ctx, refs)
+	if err != nil || len(docs) != len(refs) {
+		br.client.config.Logger.Warn("Failed to fetch current source of conflicting bulk updates - conflicts: %d, error: %v", len(refs), err)
+		return
+	}
+
+	for i, pos := range positions {
+		if !docs[i].Found {
+			continue
+		}
+		fields := response.Items[pos]["update"].(map[string]any)
+		fields["get"] = map[string]any{
+			"found":    true,
+			"_version": float64(docs[i].Version),
+			"_source":  docs[i].Source,
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package elastic
+
+// BulkItemResult represents the outcome of a single operation in a bulk response
+type BulkItemResult struct {
+	Action  string      // index, create, update or delete
+	Index   string      // index the operation targeted
+	ID      string      // document ID
+	Version int64       // document version after the operation
+	Result  string      // created, updated, deleted, noop or not_found
+	Status  int         // HTTP status of the operation
+	Error   *ErrorCause // set when the operation failed
+
+	// Source is set for updates made with ReturnSource: the updated document, or the current
+	// document when the update failed with a version conflict, so a retry can be rebuilt against it
+	Source map[string]any
+}
+
+// Failed returns true if the operation failed
+func (r BulkItemResult) Failed() bool {
+	return r.Error != nil || r.Status >= 300
+}
+
+// Results parses the raw bulk response items into typed results, in request order
+func (br *BulkResponse) Results() []BulkItemResult {
+	results := make([]BulkItemResult, 0, len(br.Items))
+	for _, item := range br.Items {
+		for action, raw := range item {
+			results = append(results, parseBulkItem(action, raw))
+		}
+	}
+	return results
+}
+
+// FailedItems returns only the results of operations that failed
+func (br *BulkResponse) FailedItems() []BulkItemResult {
+	var failed []BulkItemResult
+	for _, result := range br.Results() {
+		if result.Failed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// CountByAction returns the number of successful operations per result
+// (e.g. "created", "updated", "deleted", "noop", "not_found")
+func (br *BulkResponse) CountByAction() map[string]int {
+	counts := make(map[string]int)
+	for _, result := range br.Results() {
+		if result.Failed() || result.Result == "" {
+			continue
+		}
+		counts[result.Result]++
+	}
+	return counts
+}
+
+// IndexedCount returns the number of operations that succeeded
+func (br *BulkResponse) IndexedCount() int {
+	count := 0
+	for _, result := range br.Results() {
+		if !result.Failed() {
+			count++
+		}
+	}
+	return count
+}
+
+// FailedCount returns the number of operations that failed
+func (br *BulkResponse) FailedCount() int {
+	if !br.Errors {
+		return 0
+	}
+	return len(br.FailedItems())
+}
+
+// parseBulkItem converts a raw bulk response item into a BulkItemResult
+func parseBulkItem(action string, raw any) BulkItemResult {
+	result := BulkItemResult{Action: action}
+
+	fields, ok := raw.(map[string]any)
+	if !ok {
+		return result
+	}
+
+	if index, ok := fields["_index"].(string); ok {
+		result.Index = index
+	}
+	if id, ok := fields["_id"].(string); ok {
+		result.ID = id
+	}
+	if version, ok := fields["_version"].(float64); ok {
+		result.Version = int64(version)
+	}
+	if res, ok := fields["result"].(string); ok {
+		result.Result = res
+	}
+	if status, ok := fields["status"].(float64); ok {
+		result.Status = int(status)
+	}
+	if errMap, ok := fields["error"].(map[string]any); ok {
+		result.Error = parseErrorCause(errMap)
+	}
+	if get, ok := fields["get"].(map[string]any); ok {
+		if source, ok := get["_source"].(map[string]any); ok {
+			result.Source = source
+		}
+	}
+
+	return result
+}
+
+// parseErrorCause converts a raw error object into an ErrorCause
+func parseErrorCause(errMap map[string]any) *ErrorCause {
+	cause := &ErrorCause{}
+	if errType, ok := errMap["type"].(string); ok {
+		cause.Type = errType
+	}
+	if reason, ok := errMap["reason"].(string); ok {
+		cause.Reason = reason
+	}
+	if causedBy, ok := errMap["caused_by"].(map[string]any); ok {
+		cause.CausedBy = parseErrorCause(causedBy)
+	}
+	return cause
+}
@@ -0,0 +1,731 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+const testBulkResponse = `{
+	"took": 30,
+	"errors": true,
+	"items": [
+		{"index": {"_index": "products", "_id": "1", "_version": 1, "result": "created", "status": 201}},
+		{"index": {"_index": "products", "_id": "2", "_version": 3, "result": "updated", "status": 200}},
+		{"delete": {"_index": "products", "_id": "3", "_version": 2, "result": "deleted", "status": 200}},
+		{"create": {"_index": "products", "_id": "4", "status": 409,
+			"error": {"type": "version_conflict_engine_exception", "reason": "document already exists"}}},
+		{"index": {"_index": "products", "_id": "5", "status": 400,
+			"error": {"type": "mapper_parsing_exception", "reason": "failed to parse",
+				"caused_by": {"type": "illegal_argument_exception", "reason": "bad value"}}}}
+	]
+}`
+
+func TestBulkResponseStats(t *testing.T) {
+	var response BulkResponse
+	if err := json.Unmarshal([]byte(testBulkResponse), &response); err != nil {
+		t.Fatalf("Failed to unmarshal bulk response: %v", err)
+	}
+
+	if got := response.IndexedCount(); got != 3 {
+		t.Errorf("Expected 3 successful items, got %d", got)
+	}
+	if got := response.FailedCount(); got != 2 {
+		t.Errorf("Expected 2 failed items, got %d", got)
+	}
+
+	counts := response.CountByAction()
+	for result, expected := range map[string]int{"created": 1, "updated": 1, "deleted": 1} {
+		if counts[result] != expected {
+			t.Errorf("Expected %d %s items, got %d", expected, result, counts[result])
+		}
+	}
+
+	failed := response.FailedItems()
+	if len(failed) != 2 {
+		t.Fatalf("Expected 2 failed items, got %d", len(failed))
+	}
+	if failed[0].Action != "create" || failed[0].ID != "4" || failed[0].Status != 409 {
+		t.Errorf("Unexpected first failed item: %+v", failed[0])
+	}
+	if failed[1].Error == nil || failed[1].Error.CausedBy == nil || failed[1].Error.CausedBy.Type != "illegal_argument_exception" {
+		t.Errorf("Expected nested error cause to be parsed, got %+v", failed[1].Error)
+	}
+}
+
+func TestRetryableBulkPositions(t *testing.T) {
+	items := []map[string]any{
+		{"index": map[string]any{"_id": "1", "status": float64(201)}},
+		{"index": map[string]any{"_id": "2", "status": float64(429)}},
+		{"index": map[string]any{"_id": "3", "status": float64(400), "error": map[string]any{"type": "mapper_parsing_exception"}}},
+		{"create": map[string]any{"_id": "4", "status": float64(503)}},
+	}
+
+	positions := retryableBulkPositions(items)
+	if len(positions) != 2 || positions[0] != 1 || positions[1] != 3 {
+		t.Errorf("Expected retryable positions [1 3], got %v", positions)
+	}
+}
+
+func TestDefaultBulkRetryBackoff(t *testing.T) {
+	if got := defaultBulkRetryBackoff(1); got != 100*time.Millisecond {
+		t.Errorf("Expected first backoff 100ms, got %v", got)
+	}
+	if got := defaultBulkRetryBackoff(3); got != 400*time.Millisecond {
+		t.Errorf("Expected third backoff 400ms, got %v", got)
+	}
+	if got := defaultBulkRetryBackoff(100); got != 30*time.Second {
+		t.Errorf("Expected backoff to be capped at 30s, got %v", got)
+	}
+}
+
+func TestBulkBodyPromotesGeneratedID(t *testing.T) {
+	client := &Client{config: &Config{IDMode: IDModeULID}}
+	bulk := &BulkResource{client: client}
+
+	var body strings.Builder
+	err := bulk.writeBody(&body, []*BulkOperation{
+		bulk.Create("products", "", map[string]any{"name": "widget"}),
+		bulk.Index("products", "explicit-id", map[string]any{"name": "gadget"}),
+	})
+	if err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 NDJSON lines, got %d", len(lines))
+	}
+
+	var createAction map[string]map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &createAction); err != nil {
+		t.Fatalf("Failed to decode action line: %v", err)
+	}
+	if id, ok := createAction["create"]["_id"].(string); !ok || len(id) != 26 {
+		t.Errorf("Expected action line to carry a 26-character ULID, got %v", createAction["create"]["_id"])
+	}
+
+	operations := []*BulkOperation{bulk.Create("products", "", map[string]any{"name": "widget"})}
+	var first, second strings.Builder
+	if err := bulk.writeBody(&first, operations); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+	if err := bulk.writeBody(&second, operations); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+	firstAction, _, _ := strings.Cut(first.String(), "\n")
+	secondAction, _, _ := strings.Cut(second.String(), "\n")
+	if operations[0].ID == "" || firstAction != secondAction {
+		t.Errorf("Expected a resent operation to keep its generated ID, got %s and %s", firstAction, secondAction)
+	}
+
+	var indexAction map[string]map[string]any
+	if err := json.Unmarshal([]byte(lines[2]), &indexAction); err != nil {
+		t.Fatalf("Failed to decode action line: %v", err)
+	}
+	if indexAction["index"]["_id"] != "explicit-id" {
+		t.Errorf("Expected explicit ID to win over the generated one, got %v", indexAction["index"]["_id"])
+	}
+
+	for _, line := range []string{lines[1], lines[3]} {
+		var source map[string]any
+		if err := json.Unmarshal([]byte(line), &source); err != nil {
+			t.Fatalf("Failed to decode document line: %v", err)
+		}
+		if _, exists := source["_id"]; exists {
+			t.Errorf("Expected no _id in document source, got %v", source)
+		}
+	}
+}
+
+func TestDeleteMany(t *testing.T) {
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 3, "errors": false, "items": [
+			{"delete": {"_index": "products", "_id": "1", "result": "deleted", "status": 200}},
+			{"delete": {"_index": "products", "_id": "2", "result": "not_found", "status": 404}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	response, err := client.Documents().DeleteMany(context.Background(), "products", []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"delete":{"_id":"1","_index":"products"}}` + "\n" + `{"delete":{"_id":"2","_index":"products"}}` + "\n"
+	if requestBody != expected {
+		t.Errorf("Expected body %q, got %q", expected, requestBody)
+	}
+	if counts := response.CountByAction(); counts["deleted"] != 1 {
+		t.Errorf("Expected 1 deleted document, got %v", counts)
+	}
+
+	empty, err := client.Documents().DeleteMany(context.Background(), "products", nil)
+	if err != nil || len(empty.Items) != 0 {
+		t.Errorf("Expected an empty response for no IDs, got %+v (%v)", empty, err)
+	}
+}
+
+func TestUpdateMany(t *testing.T) {
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 3, "errors": false, "items": [
+			{"update": {"_index": "products", "_id": "a", "result": "updated", "status": 200}},
+			{"update": {"_index": "products", "_id": "b", "result": "noop", "status": 200}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:               []string{strings.TrimPrefix(server.URL, "http://")},
+		LazyConnect:         true,
+		SkipUpdateTimestamp: true,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	response, err := client.Documents().UpdateMany(context.Background(), "products", map[string]map[string]any{
+		"b": {"stock": 0},
+		"a": {"price": 12.5},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"update":{"_id":"a","_index":"products"}}` + "\n" + `{"doc":{"price":12.5}}` + "\n" +
+		`{"update":{"_id":"b","_index":"products"}}` + "\n" + `{"doc":{"stock":0}}` + "\n"
+	if requestBody != expected {
+		t.Errorf("Expected body %q, got %q", expected, requestBody)
+	}
+	if response.IndexedCount() != 2 {
+		t.Errorf("Expected 2 successful updates, got %d", response.IndexedCount())
+	}
+}
+
+func TestBulkUpdateSourceOnConflict(t *testing.T) {
+	var bulkBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_bulk":
+			bulkBody = string(body)
+			_, _ = w.Write([]byte(`{"took": 4, "errors": true, "items": [
+				{"update": {"_index": "products", "_id": "a", "result": "updated", "status": 200,
+					"get": {"found": true, "_source": {"stock": 5}}}},
+				{"update": {"_index": "products", "_id": "b", "status": 409,
+					"error": {"type": "version_conflict_engine_exception", "reason": "version conflict"}}}
+			]}`))
+		case "/_mget":
+			if !strings.Contains(string(body), `"_id":"b"`) || strings.Contains(string(body), `"_id":"a"`) {
+				t.Errorf("Expected only the conflicting document to be fetched, got %s", body)
+			}
+			_, _ = w.Write([]byte(`{"docs": [{"_index": "products", "_id": "b", "_version": 7, "found": true, "_source": {"stock": 2}}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	response, err := client.Documents().Bulk("products").
+		Update("a", map[string]any{"stock": 5}, WithUpdateSource(true)).
+		Update("b", map[string]any{"stock": 3}, WithUpdateSource(true)).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Count(bulkBody, `"_source":true`) != 2 {
+		t.Errorf("Expected both update lines to request _source, got %q", bulkBody)
+	}
+
+	results := response.Results()
+	if results[0].Source["stock"] != float64(5) {
+		t.Errorf("Expected updated source for a, got %v", results[0].Source)
+	}
+	if results[1].Status != 409 || results[1].Source["stock"] != float64(2) {
+		t.Errorf("Expected current source for conflicting b, got %+v", results[1])
+	}
+}
+
+// benchmarkBulkOperations returns count index operations with struct documents
+func benchmarkBulkOperations(bulk *BulkResource, count int) []*BulkOperation {
+	type product struct {
+		Name  string   `json:"name"`
+		Price float64  `json:"price"`
+		Tags  []string `json:"tags"`
+	}
+
+	operations := make([]*BulkOperation, count)
+	for i := range operations {
+		operations[i] = bulk.Index("products", fmt.Sprintf("product-%d", i), product{
+			Name:  "widget",
+			Price: 9.99,
+			Tags:  []string{"tools", "hardware"},
+		})
+	}
+	return operations
+}
+
+// BenchmarkBulkBodyPooled builds bulk bodies the way Execute does, reusing pooled buffers
+func BenchmarkBulkBodyPooled(b *testing.B) {
+	bulk := &BulkResource{client: &Client{config: &Config{SkipUpdateTimestamp: true}}}
+	operations := benchmarkBulkOperations(bulk, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body := getBuffer()
+		if err := bulk.writeBody(body, operations); err != nil {
+			b.Fatal(err)
+		}
+		putBuffer(body)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(operations)), "ns/doc")
+}
+
+// BenchmarkBulkBodyUnpooled builds bulk bodies into a fresh buffer per request, for comparison
+func BenchmarkBulkBodyUnpooled(b *testing.B) {
+	bulk := &BulkResource{client: &Client{config: &Config{SkipUpdateTimestamp: true}}}
+	operations := benchmarkBulkOperations(bulk, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var body bytes.Buffer
+		if err := bulk.writeBody(&body, operations); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(operations)), "ns/doc")
+}
+
+func TestBulkBodyPerOperationMetadata(t *testing.T) {
+	client := &Client{config: &Config{}}
+	bulk := &BulkResource{client: client}
+
+	counter := bulk.Update("stats", "page-1", nil)
+	counter.Script = map[string]any{"source": "ctx._source.views += 1"}
+	counter.RetryOnConflict = 3
+	counter.Routing = "tenant-a"
+	counter.ReturnSource = true
+
+	plain := bulk.Update("stats", "page-2", map[string]any{"views": 0})
+
+	var body strings.Builder
+	if err := bulk.writeBody(&body, []*BulkOperation{counter, plain}); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 NDJSON lines, got %d", len(lines))
+	}
+
+	var action map[string]map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &action); err != nil {
+		t.Fatalf("Failed to decode action line: %v", err)
+	}
+	if action["update"]["retry_on_conflict"] != float64(3) || action["update"]["routing"] != "tenant-a" {
+		t.Errorf("Expected retry_on_conflict and routing on the action line, got %v", action["update"])
+	}
+	if !strings.Contains(lines[1], `"_source":true`) {
+		t.Errorf("Expected the first update to request its source, got %s", lines[1])
+	}
+
+	if strings.Contains(lines[2], "retry_on_conflict") || strings.Contains(lines[2], "routing") || strings.Contains(lines[3], "_source") {
+		t.Errorf("Expected the second update to carry no per-operation options, got %s / %s", lines[2], lines[3])
+	}
+
+	invalid := bulk.Index("stats", "page-3", map[string]any{"views": 1})
+	invalid.RetryOnConflict = 2
+	if err := bulk.writeBody(&strings.Builder{}, []*BulkOperation{invalid}); err == nil {
+		t.Error("Expected retry_on_conflict on an index operation to be rejected")
+	}
+}
+
+func TestBulkBodyPreservesOperationOrder(t *testing.T) {
+	client := &Client{config: &Config{}}
+	bulk := &BulkResource{client: client}
+
+	operations := []*BulkOperation{
+		bulk.Index("products", "1", map[string]any{"version": 1}),
+		bulk.Index("products", "2", map[string]any{"version": 1}),
+		bulk.Update("products", "1", map[string]any{"version": 2}),
+		bulk.Delete("products", "1"),
+		bulk.Index("products", "1", map[string]any{"version": 3}),
+	}
+
+	var body strings.Builder
+	if err := bulk.writeBody(&body, operations); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+
+	var actions []string
+	var versions []any
+	for _, line := range strings.Split(strings.TrimSpace(body.String()), "\n") {
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("Failed to decode line %s: %v", line, err)
+		}
+		for _, action := range []string{"index", "update", "delete"} {
+			if meta, ok := decoded[action].(map[string]any); ok {
+				actions = append(actions, action+":"+meta["_id"].(string))
+			}
+		}
+		if version, ok := decoded["version"]; ok {
+			versions = append(versions, version)
+		} else if doc, ok := decoded["doc"].(map[string]any); ok {
+			versions = append(versions, doc["version"])
+		}
+	}
+
+	expectedActions := []string{"index:1", "index:2", "update:1", "delete:1", "index:1"}
+	if fmt.Sprint(actions) != fmt.Sprint(expectedActions) {
+		t.Errorf("Expected actions %v in submission order, got %v", expectedActions, actions)
+	}
+	if fmt.Sprint(versions) != "[1 1 2 3]" {
+		t.Errorf("Expected sources in submission order [1 1 2 3], got %v", versions)
+	}
+}
+
+func TestDoWithRetryKeepsWritesInOrder(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(body))
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if len(requests) == 1 {
+			// The first write to doc 1 is rejected, the later one succeeds; doc 2 is rejected
+			_, _ = w.Write([]byte(`{"took": 5, "errors": true, "items": [
+				{"index": {"_index": "products", "_id": "1", "status": 429, "error": {"type": "es_rejected_execution_exception"}}},
+				{"index": {"_index": "products", "_id": "1", "status": 200, "result": "updated"}},
+				{"index": {"_index": "products", "_id": "2", "status": 429, "error": {"type": "es_rejected_execution_exception"}}}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"took": 2, "errors": false, "items": [
+			{"index": {"_index": "products", "_id": "2", "status": 201, "result": "created"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	response, err := client.Documents().Bulk("products").
+		Index("1", map[string]any{"version": 1}).
+		Index("1", map[string]any{"version": 2}).
+		Index("2", map[string]any{"version": 1}).
+		DoWithRetry(context.Background(), 3, func(int) time.Duration { return 0 })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected one retry request, got %d requests", len(requests))
+	}
+	if strings.Contains(requests[1], `"_id":"1"`) || !strings.Contains(requests[1], `"_id":"2"`) {
+		t.Errorf("Expected only doc 2 to be retried, got %s", requests[1])
+	}
+
+	results := response.Results()
+	if results[0].Status != 429 || results[1].Status != 200 || results[2].Status != 201 {
+		t.Errorf("Expected the superseded write to keep its failure, got %+v", results)
+	}
+}
+
+func TestBulkBodyRequireAlias(t *testing.T) {
+	client := &Client{config: &Config{}}
+	bulk := &BulkResource{client: client}
+
+	operations := []*BulkOperation{
+		bulk.Index("logs", "1", map[string]any{"message": "a"}, WithRequireAlias(true)),
+		bulk.Create("logs", "2", map[string]any{"message": "b"}),
+	}
+
+	var body strings.Builder
+	if err := bulk.writeBody(&body, operations); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+	if !strings.Contains(lines[0], `"require_alias":true`) {
+		t.Errorf("Expected require_alias on the first action line, got %s", lines[0])
+	}
+	if strings.Contains(lines[2], "require_alias") {
+		t.Errorf("Expected no require_alias on the second action line, got %s", lines[2])
+	}
+
+	invalid := bulk.Delete("logs", "1")
+	invalid.RequireAlias = true
+	if err := bulk.writeBody(&strings.Builder{}, []*BulkOperation{invalid}); err == nil {
+		t.Error("Expected require_alias on a delete operation to be rejected")
+	}
+}
+
+// deferredBodyTransport answers bulk requests without reading their bodies, which it keeps so the
+// test can read them after RoundTrip has returned, as http.Transport may for early responses
+type deferredBodyTransport struct {
+	bodies []io.ReadCloser
+}
+
+func (t *deferredBodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.bodies = append(t.bodies, req.Body)
+
+	header := make(http.Header)
+	header.Set("X-Elastic-Product", "Elasticsearch")
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"took": 1, "errors": false, "items": [{"index": {"_id": "1", "status": 201}}]}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestBulkBodyOutlivesRoundTrip(t *testing.T) {
+	transport := &deferredBodyTransport{}
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:        []string{"localhost:9200"},
+		LazyConnect:  true,
+		DisableRetry: true,
+		Transport:    transport,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := context.Background()
+	for _, name := range []string{"first", "second"} {
+		if _, err := client.Documents().Bulk("products").Index("1", map[string]any{"name": name}).Do(ctx); err != nil {
+			t.Fatalf("Unexpected bulk error: %v", err)
+		}
+	}
+
+	if len(transport.bodies) != 2 {
+		t.Fatalf("Expected 2 bulk requests, got %d", len(transport.bodies))
+	}
+	first, err := io.ReadAll(transport.bodies[0])
+	if err != nil {
+		t.Fatalf("Failed to read the first body: %v", err)
+	}
+	if !strings.Contains(string(first), `"name":"first"`) {
+		t.Errorf("Expected the first body to be intact after a later bulk request, got %s", first)
+	}
+	_ = transport.bodies[1].Close()
+}
+
+func TestPooledBodyReleasesOnce(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString(`{"name":"phone"}`)
+	body := newPooledBody(buf)
+
+	first := body.reader()
+	retry, _ := body.getBody()
+	body.release()
+
+	data, err := io.ReadAll(first)
+	if err != nil || string(data) != `{"name":"phone"}` {
+		t.Fatalf("Expected the buffered body, got %q (%v)", data, err)
+	}
+	if err := first.Close(); err != nil {
+		t.Errorf("Expected Close after EOF to succeed, got %v", err)
+	}
+	if body.buf == nil {
+		t.Fatal("Expected the buffer to be kept while a GetBody copy is open")
+	}
+
+	data, _ = io.ReadAll(retry)
+	if string(data) != `{"name":"phone"}` {
+		t.Errorf("Expected the GetBody copy to share the buffered bytes, got %q", data)
+	}
+	if body.buf != nil {
+		t.Error("Expected the buffer to be released once every reader is done")
+	}
+	if n, err := retry.Read(make([]byte, 8)); n != 0 || err != io.EOF {
+		t.Errorf("Expected EOF after release, got %d, %v", n, err)
+	}
+}
+
+// BenchmarkPrepareDocumentPooled marshals single documents the way Index and Create do
+func BenchmarkPrepareDocumentPooled(b *testing.B) {
+	client := &Client{config: &Config{SkipUpdateTimestamp: true}}
+	document := map[string]any{"name": "widget", "price": 9.99, "tags": []string{"tools", "hardware"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, body, err := client.prepareDocument("1", document)
+		if err != nil {
+			b.Fatal(err)
+		}
+		newPooledBody(body).release()
+	}
+}
+
+// discardTransport reads and discards request bodies and answers every request with a bulk response
+type discardTransport struct{}
+
+func (discardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	header := make(http.Header)
+	header.Set("X-Elastic-Product", "Elasticsearch")
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"took": 1, "errors": false, "items": []}`)),
+		Request:    req,
+	}, nil
+}
+
+// newBenchmarkClient creates a client that sends requests to discardTransport, with retries on
+func newBenchmarkClient(b *testing.B) *Client {
+	b.Helper()
+
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:               []string{"localhost:9200"},
+		LazyConnect:         true,
+		SkipUpdateTimestamp: true,
+		Logger:              &NopLogger{},
+		Transport:           discardTransport{},
+	}))
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+	b.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// BenchmarkBulkExecutePooled sends bulk requests through the transport the way Execute does
+func BenchmarkBulkExecutePooled(b *testing.B) {
+	client := newBenchmarkClient(b)
+	bulk := &BulkResource{client: client}
+	operations := benchmarkBulkOperations(bulk, 1000)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := bulk.Execute(ctx, operations); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBulkExecuteUnpooled sends the same bulk requests from a fresh buffer per request, for comparison
+func BenchmarkBulkExecuteUnpooled(b *testing.B) {
+	client := newBenchmarkClient(b)
+	bulk := &BulkResource{client: client}
+	operations := benchmarkBulkOperations(bulk, 1000)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var body bytes.Buffer
+		if err := bulk.writeBody(&body, operations); err != nil {
+			b.Fatal(err)
+		}
+		res, err := esapi.BulkRequest{Body: bytes.NewReader(body.Bytes())}.Do(ctx, client.client)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+}
+
+func TestDoWithRetryRetriesRequestLevelFailures(t *testing.T) {
+	for _, tc := range []struct {
+		status   int
+		requests int
+		retried  bool
+	}{
+		{status: http.StatusTooManyRequests, requests: 2, retried: true},
+		{status: http.StatusServiceUnavailable, requests: 1, retried: false},
+		{status: http.StatusBadRequest, requests: 1, retried: false},
+	} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Header().Set("Content-Type", "application/json")
+			if requests == 1 {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(`{"error": {"type": "es_rejected_execution_exception"}, "status": ` + fmt.Sprint(tc.status) + `}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"took": 2, "errors": false, "items": [
+				{"index": {"_index": "products", "_id": "1", "status": 201, "result": "created"}}
+			]}`))
+		}))
+
+		// Transport retries are off so the whole-request failure reaches DoWithRetry
+		client, err := NewClient(WithConfig(&Config{
+			Hosts:        []string{strings.TrimPrefix(server.URL, "http://")},
+			LazyConnect:  true,
+			DisableRetry: true,
+		}))
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		response, err := client.Documents().Bulk("products").
+			Index("1", map[string]any{"name": "phone"}).
+			DoWithRetry(context.Background(), 3, func(int) time.Duration { return 0 })
+
+		if requests != tc.requests {
+			t.Errorf("Expected %d requests for status %d, got %d", tc.requests, tc.status, requests)
+		}
+		if tc.retried {
+			if err != nil || response.Errors {
+				t.Errorf("Expected status %d to be retried successfully, got %v", tc.status, err)
+			}
+		} else {
+			var requestErr *BulkRequestError
+			if !errors.As(err, &requestErr) || requestErr.StatusCode != tc.status || !errors.Is(err, ErrBulkRequestFailed) {
+				t.Errorf("Expected a BulkRequestError with status %d, got %v", tc.status, err)
+			}
+		}
+
+		_ = client.Close()
+		server.Close()
+	}
+}
@@ -0,0 +1,222 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// ByQueryOptions configures an update_by_query or delete_by_query request
+type ByQueryOptions struct {
+	ScrollSize        int  // Documents fetched per batch (0 = Elasticsearch default of 1000)
+	RequestsPerSecond int  // Throttle in sub-requests per second (0 = unthrottled)
+	Slices            int  // Number of slices the task is divided into and run in parallel (0 = 1)
+	AutoSlices        bool // Let Elasticsearch pick one slice per shard ("slices": "auto"); cannot be combined with Slices
+}
+
+// Validate checks that the options hold supported values
+func (o ByQueryOptions) Validate() error {
+	if o.ScrollSize < 0 {
+		return fmt.Errorf("by-query scroll_size cannot be negative, got %d", o.ScrollSize)
+	}
+	if o.RequestsPerSecond < 0 {
+		return fmt.Errorf("by-query requests_per_second cannot be negative, got %d", o.RequestsPerSecond)
+	}
+	if o.Slices < 0 {
+		return fmt.Errorf("by-query slices cannot be negative, got %d", o.Slices)
+	}
+	if o.AutoSlices && o.Slices > 0 {
+		return fmt.Errorf("by-query slices %d and auto slices are mutually exclusive", o.Slices)
+	}
+	return nil
+}
+
+// scrollSize returns the scroll_size parameter, or nil to use the default
+func (o ByQueryOptions) scrollSize() *int {
+	if o.ScrollSize == 0 {
+		return nil
+	}
+	return &o.ScrollSize
+}
+
+// requestsPerSecond returns the requests_per_second parameter, or nil to run unthrottled
+func (o ByQueryOptions) requestsPerSecond() *int {
+	if o.RequestsPerSecond == 0 {
+		return nil
+	}
+	return &o.RequestsPerSecond
+}
+
+// slices returns the slices parameter, or nil to run a single slice
+func (o ByQueryOptions) slices() any {
+	if o.AutoSlices {
+		return "auto"
+	}
+	if o.Slices == 0 {
+		return nil
+	}
+	return o.Slices
+}
+
+// ByQueryResult represents the result of an update_by_query or delete_by_query request
+type ByQueryResult struct {
+	Took             int64           `json:"took"`
+	TimedOut         bool            `json:"timed_out"`
+	Total            int64           `json:"total"`
+	Updated          int64           `json:"updated"`
+	Deleted          int64           `json:"deleted"`
+	Batches          int64           `json:"batches"`
+	VersionConflicts int64           `json:"version_conflicts"`
+	Noops            int64           `json:"noops"`
+	Failures         []BulkItemError `json:"failures"`
+}
+
+// UpdateByQueryWithOptions updates all documents matching a query in the given index with typed options
+func (s *DocumentsService) UpdateByQueryWithOptions(ctx context.Context, indexName string, query map[string]any, script map[string]any, options ByQueryOptions) (*ByQueryResult, error) {
+	doc := &Document{
+		client: s.client,
+		index:  indexName,
+	}
+	return doc.UpdateByQueryWithOptions(ctx, query, script, options)
+}
+
+// DeleteByQueryWithOptions deletes all documents matching a query in the given index with typed options
+func (s *DocumentsService) DeleteByQueryWithOptions(ctx context.Context, indexName string, query map[string]any, options ByQueryOptions) (*ByQueryResult, error) {
+	doc := &Document{
+		client: s.client,
+		index:  indexName,
+	}
+	return doc.DeleteByQueryWithOptions(ctx, query, options)
+}
+
+// UpdateByQueryWithOptions updates all documents matching a query using the _update_by_query API.
+// Set RequestsPerSecond to keep a large update from saturating a production cluster.
+func (d *Document) UpdateByQueryWithOptions(ctx context.Context, query map[string]any, script map[string]any, options ByQueryOptions) (*ByQueryResult, error) {
+	if err := d.client.checkWritable("update by query"); err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second) // Longer timeout for bulk operations
+		defer cancel()
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build the request body
+	body := map[string]any{
+		"query": query,
+	}
+	if script != nil {
+		body["script"] = script
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal update by query body: %w", err)
+	}
+
+	req := esapi.UpdateByQueryRequest{
+		Index:             []string{d.index},
+		Body:              bytes.NewReader(bodyBytes),
+		ScrollSize:        options.scrollSize(),
+		RequestsPerSecond: options.requestsPerSecond(),
+		Slices:            options.slices(),
+	}
+
+	res, err := req.Do(ctx, d.client.client)
+	if err != nil {
+		d.client.config.Logger.Error("Failed to update by query - index: %s, error: %s", d.index, err.Error())
+		return nil, fmt.Errorf("failed to update by query: %w", err)
+	}
+
+	result, err := d.decodeByQueryResult(res, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	d.client.config.Logger.Info("Update by query completed - index: %s, total: %d, updated: %d, version_conflicts: %d, failures: %d", d.index, result.Total, result.Updated, result.VersionConflicts, len(result.Failures))
+
+	return result, nil
+}
+
+// DeleteByQueryWithOptions deletes all documents matching a query using the _delete_by_query API.
+// Set RequestsPerSecond to keep a large delete from saturating a production cluster.
+func (d *Document) DeleteByQueryWithOptions(ctx context.Context, query map[string]any, options ByQueryOptions) (*ByQueryResult, error) {
+	if err := d.client.checkWritable("delete by query"); err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second) // Longer timeout for bulk operations
+		defer cancel()
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build the request body
+	body := map[string]any{
+		"query": query,
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal delete by query body: %w", err)
+	}
+
+	req := esapi.DeleteByQueryRequest{
+		Index:             []string{d.index},
+		Body:              bytes.NewReader(bodyBytes),
+		ScrollSize:        options.scrollSize(),
+		RequestsPerSecond: options.requestsPerSecond(),
+		Slices:            options.slices(),
+	}
+
+	res, err := req.Do(ctx, d.client.client)
+	if err != nil {
+		d.client.config.Logger.Error("Failed to delete by query - index: %s, error: %s", d.index, err.Error())
+		return nil, fmt.Errorf("failed to delete by query: %w", err)
+	}
+
+	result, err := d.decodeByQueryResult(res, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	d.client.config.Logger.Info("Delete by query completed - index: %s, total: %d, deleted: %d, version_conflicts: %d, failures: %d", d.index, result.Total, result.Deleted, result.VersionConflicts, len(result.Failures))
+
+	return result, nil
+}
+
+// decodeByQueryResult checks and decodes the response of an update or delete by query request
+func (d *Document) decodeByQueryResult(res *esapi.Response, operation string) (*ByQueryResult, error) {
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			d.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		d.client.config.Logger.Error("By query request failed - operation: %s_by_query, index: %s, status: %s, response: %s", operation, d.index, res.Status(), string(bodyBytes))
+		return nil, fmt.Errorf("%s by query failed: %s - %s", operation, res.Status(), string(bodyBytes))
+	}
+
+	var result ByQueryResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s by query response: %w", operation, err)
+	}
+
+	return &result, nil
+}
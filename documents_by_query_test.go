@@ -0,0 +1,106 @@
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDeleteByQueryWithOptions(t *testing.T) {
+	var requestPath string
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		params = r.URL.Query()
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 147, "timed_out": false, "total": 120, "deleted": 119, "batches": 2,
+			"version_conflicts": 1, "noops": 0, "failures": [
+				{"index": "logs", "id": "42", "status": 409,
+					"cause": {"type": "version_conflict_engine_exception", "reason": "version conflict"}}
+			]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	options := ByQueryOptions{ScrollSize: 500, RequestsPerSecond: 200, Slices: 4}
+	result, err := client.Documents().DeleteByQueryWithOptions(context.Background(), "logs", map[string]any{"match_all": map[string]any{}}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestPath != "/logs/_delete_by_query" {
+		t.Errorf("Expected path /logs/_delete_by_query, got %s", requestPath)
+	}
+	for name, expected := range map[string]string{"scroll_size": "500", "requests_per_second": "200", "slices": "4"} {
+		if got := params.Get(name); got != expected {
+			t.Errorf("Expected %s=%s, got %q", name, expected, got)
+		}
+	}
+
+	if result.Total != 120 || result.Deleted != 119 || result.Batches != 2 || result.VersionConflicts != 1 || result.Took != 147 {
+		t.Errorf("Unexpected result counts: %+v", result)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].ID != "42" || result.Failures[0].Cause.Type != "version_conflict_engine_exception" {
+		t.Errorf("Expected one version conflict failure, got %+v", result.Failures)
+	}
+}
+
+func TestUpdateByQueryDefaults(t *testing.T) {
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 12, "total": 3, "updated": 3, "batches": 1, "failures": []}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	script := map[string]any{"source": "ctx._source.views = 0"}
+	result, err := client.Documents().UpdateByQuery(context.Background(), "products", map[string]any{"match_all": map[string]any{}}, script)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"scroll_size", "requests_per_second", "slices"} {
+		if params.Has(name) {
+			t.Errorf("Expected %s to be omitted by default, got %q", name, params.Get(name))
+		}
+	}
+	if result.Updated != 3 || result.Total != 3 {
+		t.Errorf("Expected 3 updated documents, got %+v", result)
+	}
+}
+
+func TestByQueryOptionsValidate(t *testing.T) {
+	if err := (ByQueryOptions{}).Validate(); err != nil {
+		t.Errorf("Expected zero options to be valid, got %v", err)
+	}
+	if err := (ByQueryOptions{RequestsPerSecond: 500, AutoSlices: true}).Validate(); err != nil {
+		t.Errorf("Expected throttled auto slicing to be valid, got %v", err)
+	}
+	for _, options := range []ByQueryOptions{{ScrollSize: -1}, {RequestsPerSecond: -1}, {Slices: -2}, {Slices: 4, AutoSlices: true}} {
+		if err := options.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", options)
+		}
+	}
+}
+
+func TestByQueryOptionsAutoSlices(t *testing.T) {
+	if got := (ByQueryOptions{AutoSlices: true}).slices(); got != "auto" {
+		t.Errorf("Expected slices auto, got %v", got)
+	}
+	if got := (ByQueryOptions{Slices: 3}).slices(); got != 3 {
+		t.Errorf("Expected 3 slices, got %v", got)
+	}
+	if got := (ByQueryOptions{}).slices(); got != nil {
+		t.Errorf("Expected slices to be omitted, got %v", got)
+	}
+}
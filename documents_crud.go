@@ -1,7 +1,11 @@
 package elastic
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"context"
@@ -28,30 +32,40 @@ func (s *DocumentsService) MultiGet(ctx context.Context, indexName string, docum
 }
 
 // Create creates a new document with automatic ID generation
-func (s *DocumentsService) Create(ctx context.Context, indexName string, document any) (*IndexResponse, error) {
+func (s *DocumentsService) Create(ctx context.Context, indexName string, document any, options ...WriteOption) (*IndexResponse, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
 	}
-	return doc.Index(ctx, document)
+	return doc.Index(ctx, document, options...)
 }
 
 // CreateWithID creates a new document with a specific ID (fails if document already exists)
-func (s *DocumentsService) CreateWithID(ctx context.Context, indexName, documentID string, document any) (*IndexResponse, error) {
+func (s *DocumentsService) CreateWithID(ctx context.Context, indexName, documentID string, document any, options ...WriteOption) (*IndexResponse, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
 	}
-	return doc.CreateWithID(ctx, documentID, document)
+	return doc.CreateWithID(ctx, documentID, document, options...)
 }
 
 // Update updates a document
-func (s *DocumentsService) Update(ctx context.Context, indexName, documentID string, document map[string]any) (*UpdateResponse, error) {
+func (s *DocumentsService) Update(ctx context.Context, indexName, documentID string, document any, options ...UpdateOption) (*UpdateResponse, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
 	}
-	return doc.Update(ctx, documentID, document)
+	return doc.Update(ctx, documentID, document, options...)
+}
+
+// UpdateWithScript updates a document with a script, e.g. to increment a counter; combine it
+// with WithRetryOnConflict when the document is updated concurrently
+func (s *DocumentsService) UpdateWithScript(ctx context.Context, indexName, documentID string, script map[string]any, options ...UpdateOption) (*UpdateResponse, error) {
+	doc := &Document{
+		client: s.client,
+		index:  indexName,
+	}
+	return doc.UpdateWithScript(ctx, documentID, script, options...)
 }
 
 // Delete deletes a document by ID
@@ -64,12 +78,12 @@ func (s *DocumentsService) Delete(ctx context.Context, indexName, documentID str
 }
 
 // Index creates or replaces a document with a specific ID (equivalent to PUT /<index>/_doc/<id>)
-func (s *DocumentsService) Index(ctx context.Context, indexName, documentID string, document any) (*IndexResponse, error) {
+func (s *DocumentsService) Index(ctx context.Context, indexName, documentID string, document any, options ...WriteOption) (*IndexResponse, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
 	}
-	return doc.IndexWithID(ctx, documentID, document)
+	return doc.IndexWithID(ctx, documentID, document, options...)
 }
 
 // Exists checks if a document exists (more efficient than Get for existence checks)
@@ -82,7 +96,7 @@ func (s *DocumentsService) Exists(ctx context.Context, indexName, documentID str
 }
 
 // UpdateByQuery updates all documents matching a query
-func (s *DocumentsService) UpdateByQuery(ctx context.Context, indexName string, query map[string]any, script map[string]any) (map[string]any, error) {
+func (s *DocumentsService) UpdateByQuery(ctx context.Context, indexName string, query map[string]any, script map[string]any) (*ByQueryResult, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
@@ -91,7 +105,7 @@ func (s *DocumentsService) UpdateByQuery(ctx context.Context, indexName string,
 }
 
 // DeleteByQuery deletes all documents matching a query
-func (s *DocumentsService) DeleteByQuery(ctx context.Context, indexName string, query map[string]any) (map[string]any, error) {
+func (s *DocumentsService) DeleteByQuery(ctx context.Context, indexName string, query map[string]any) (*ByQueryResult, error) {
 	doc := &Document{
 		client: s.client,
 		index:  indexName,
@@ -107,27 +121,23 @@ func (s *DocumentsService) GetIndex(indexName string) *Document {
 	}
 }
 
+// Scoped returns document operations scoped to the given index, so the index name isn't
+// repeated on every call:
+//
+//	products := client.Documents().Scoped("products")
+//	products.Create(ctx, product)
+//	products.Get(ctx, id)
+func (s *DocumentsService) Scoped(indexName string) *Document {
+	return s.GetIndex(indexName)
+}
+
 // enhanceDocument adds ID and metadata to a document based on client configuration
 func (c *Client) enhanceDocument(doc any) map[string]any {
-	var docMap map[string]any
-
 	// Convert document to map
-	if m, ok := doc.(map[string]any); ok {
-		docMap = make(map[string]any)
-		for k, v := range m {
-			docMap[k] = v
-		}
-	} else {
-		// Try to convert via JSON
-		jsonBytes, err := json.Marshal(doc)
-		if err != nil {
-			c.config.Logger.Error("Failed to marshal document - error: %s", err.Error())
-			return map[string]any{}
-		}
-		if err := json.Unmarshal(jsonBytes, &docMap); err != nil {
-			c.config.Logger.Error("Failed to unmarshal document - error: %s", err.Error())
-			return map[string]any{}
-		}
+	docMap, err := documentToMap(c.codec(), doc)
+	if err != nil {
+		c.config.Logger.Error("Failed to convert document - error: %s", err.Error())
+		return map[string]any{}
 	}
 
 	// Add ID if not present and not in custom mode
@@ -155,3 +165,123 @@ func (c *Client) enhanceDocument(doc any) map[string]any {
 
 	return docMap
 }
+
+// documentToMap converts a document to a map: maps are shallow-copied, anything else
+// (structs, pointers, json.RawMessage) is converted via JSON so struct tags apply
+func documentToMap(codec Codec, doc any) (map[string]any, error) {
+	if m, ok := doc.(map[string]any); ok {
+		docMap := make(map[string]any, len(m))
+		for k, v := range m {
+			docMap[k] = v
+		}
+		return docMap, nil
+	}
+
+	jsonBytes, err := codec.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	var docMap map[string]any
+	if err := codec.Unmarshal(jsonBytes, &docMap); err != nil {
+		return nil, fmt.Errorf("failed to convert document to an object: %w", err)
+	}
+	if docMap == nil {
+		docMap = map[string]any{}
+	}
+	return docMap, nil
+}
+
+// buildPartialUpdate wraps a partial document (map or struct) in an update request body,
+// adding updated_at unless the document sets it or Config.SkipUpdateTimestamp is set
+func (c *Client) buildPartialUpdate(doc any) (map[string]any, error) {
+	// Copying maps means the caller's document isn't modified
+	partial, err := documentToMap(c.codec(), doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, exists := partial["updated_at"]; !exists && !c.config.SkipUpdateTimestamp {
+		partial["updated_at"] = time.Now()
+	}
+
+	return map[string]any{
+		"doc": partial,
+	}, nil
+}
+
+// checkWritable returns ErrReadOnly for the given write operation when the client is read-only
+func (c *Client) checkWritable(operation string) error {
+	if c.config.ReadOnly {
+		return fmt.Errorf("%w: %s not allowed", ErrReadOnly, operation)
+	}
+	return nil
+}
+
+// validateDocument runs the configured document validator, if any
+func (c *Client) validateDocument(document any) error {
+	if c.config.DocumentValidator == nil {
+		return nil
+	}
+	if err := c.config.DocumentValidator(document); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+	return nil
+}
+
+// prepareDocument enhances a document and marshals it into a pooled buffer for a single-document
+// request; send it through newPooledBody. The returned ID is documentID, or the ID
+// generated by the ID mode when documentID is empty; either way _id is stripped from the body so
+// it doesn't end up in _source.
+func (c *Client) prepareDocument(documentID string, document any) (string, *bytes.Buffer, error) {
+	if err := c.validateDocument(document); err != nil {
+		return "", nil, err
+	}
+
+	enhancedDoc := c.enhanceDocument(document)
+
+	generatedID, err := takeDocumentID(enhancedDoc)
+	if err != nil {
+		return "", nil, err
+	}
+	if documentID == "" {
+		documentID = generatedID
+	}
+
+	body := getBuffer()
+	if err := encodeJSON(body, c.codec(), enhancedDoc); err != nil {
+		putBuffer(body)
+		return "", nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	return documentID, body, nil
+}
+
+// takeDocumentID removes the "_id" key from an enhanced document and returns it.
+// _id is metadata that belongs in the request path or bulk action line, never in _source.
+// Numeric IDs, e.g. from an int field tagged `json:"_id"`, are formatted in decimal; an _id
+// that can't be turned into the same string Elasticsearch would store is an error.
+func takeDocumentID(docMap map[string]any) (string, error) {
+	id, exists := docMap["_id"]
+	if !exists {
+		return "", nil
+	}
+	delete(docMap, "_id")
+
+	switch v := id.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float64:
+		// JSON numbers decode to float64, which holds integers exactly only up to 2^53
+		if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return "", fmt.Errorf("document _id %v cannot be represented exactly, use a string ID", v)
+	default:
+		return "", fmt.Errorf("document _id must be a string or an integer, got %T", id)
+	}
+}
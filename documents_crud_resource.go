@@ -18,46 +18,52 @@ type Document struct {
 	index  string
 }
 
+// Name returns the index the document operations are scoped to
+func (d *Document) Name() string {
+	return d.index
+}
+
+// Create creates a new document with automatic ID generation
+func (d *Document) Create(ctx context.Context, document any, options ...WriteOption) (*IndexResponse, error) {
+	return d.Index(ctx, document, options...)
+}
+
 // Index indexes a document with automatic ID generation
-func (d *Document) Index(ctx context.Context, document any) (*IndexResponse, error) {
-	return d.IndexWithID(ctx, "", document)
+func (d *Document) Index(ctx context.Context, document any, options ...WriteOption) (*IndexResponse, error) {
+	return d.IndexWithID(ctx, "", document, options...)
 }
 
 // IndexWithID indexes a document with a specific ID
-func (d *Document) IndexWithID(ctx context.Context, documentID string, document any) (*IndexResponse, error) {
+func (d *Document) IndexWithID(ctx context.Context, documentID string, document any, options ...WriteOption) (*IndexResponse, error) {
+	if err := d.client.checkWritable("index document"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second) //nolint:ineffassign
 		defer cancel()
 	}
 
-	// Enhance document with metadata
-	enhancedDoc := d.client.enhanceDocument(document)
-
-	// Use the _id from enhanced document if no ID provided
-	if documentID == "" {
-		if id, exists := enhancedDoc["_id"]; exists {
-			if idStr, ok := id.(string); ok {
-				documentID = idStr
-			}
-		}
-	}
-
-	// Convert document to JSON
-	docBytes, err := json.Marshal(enhancedDoc)
+	// Enhance document with metadata, using the generated _id if no ID provided
+	documentID, body, err := d.client.prepareDocument(documentID, document)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal document: %w", err)
+		return nil, err
 	}
 
+	pooled := newPooledBody(body)
+	defer pooled.release()
+
 	// Prepare the index request
 	req := esapi.IndexRequest{
-		Index:      d.index,
-		DocumentID: documentID,
-		Body:       bytes.NewReader(docBytes),
-		Refresh:    "wait_for",
+		Index:        d.index,
+		DocumentID:   documentID,
+		Body:         pooled.reader(),
+		Refresh:      "wait_for",
+		RequireAlias: buildWriteOptions(options).requireAliasParam(),
 	}
 
-	res, err := req.Do(ctx, d.client.client)
+	res, err := req.Do(ctx, pooled.transport(d.client.client))
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute index request: %w", err)
 	}
@@ -206,24 +212,46 @@ func (d *Document) GetMany(ctx context.Context, documentIDs []string) ([]map[str
 }
 
 // Update updates a document
-func (d *Document) Update(ctx context.Context, documentID string, doc map[string]any) (*UpdateResponse, error) {
-	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second) //nolint:ineffassign
-		defer cancel()
+func (d *Document) Update(ctx context.Context, documentID string, doc any, options ...UpdateOption) (*UpdateResponse, error) {
+	if err := d.client.checkWritable("update document"); err != nil {
+		return nil, err
 	}
 
 	// Wrap the document in an update request
-	updateDoc := map[string]any{
-		"doc": doc,
+	updateDoc, err := d.client.buildPartialUpdate(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.update(ctx, documentID, updateDoc, buildUpdateOptions(options))
+}
+
+// UpdateWithScript updates a document with a script
+func (d *Document) UpdateWithScript(ctx context.Context, documentID string, script map[string]any, options ...UpdateOption) (*UpdateResponse, error) {
+	if err := d.client.checkWritable("update document"); err != nil {
+		return nil, err
 	}
 
-	// Add updated_at timestamp
-	if _, exists := doc["updated_at"]; !exists {
-		updateDoc["doc"].(map[string]any)["updated_at"] = time.Now()
+	if script == nil {
+		return nil, fmt.Errorf("script cannot be nil")
 	}
 
-	docBytes, err := json.Marshal(updateDoc)
+	return d.update(ctx, documentID, map[string]any{"script": script}, buildUpdateOptions(options))
+}
+
+// update sends an update request with the given body
+func (d *Document) update(ctx context.Context, documentID string, updateDoc map[string]any, opts updateOptions) (*UpdateResponse, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second) //nolint:ineffassign
+		defer cancel()
+	}
+
+	docBytes, err := d.client.codec().Marshal(updateDoc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal update document: %w", err)
 	}
@@ -234,6 +262,12 @@ func (d *Document) Update(ctx context.Context, documentID string, doc map[string
 		Body:       bytes.NewReader(docBytes),
 		Refresh:    "wait_for",
 	}
+	if opts.source {
+		req.Source = []string{"true"}
+	}
+	if opts.retryOnConflict > 0 {
+		req.RetryOnConflict = &opts.retryOnConflict
+	}
 
 	res, err := req.Do(ctx, d.client.client)
 	if err != nil {
@@ -262,6 +296,10 @@ func (d *Document) Update(ctx context.Context, documentID string, doc map[string
 
 // Delete deletes a document
 func (d *Document) Delete(ctx context.Context, documentID string) (*DeleteResponse, error) {
+	if err := d.client.checkWritable("delete document"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second) //nolint:ineffassign
@@ -343,7 +381,11 @@ func (d *Document) Exists(ctx context.Context, documentID string) (bool, error)
 }
 
 // CreateWithID creates a document with a specific ID using the _create endpoint (fails if document exists)
-func (d *Document) CreateWithID(ctx context.Context, documentID string, document any) (*IndexResponse, error) {
+func (d *Document) CreateWithID(ctx context.Context, documentID string, document any, options ...WriteOption) (*IndexResponse, error) {
+	if err := d.client.checkWritable("create document"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -351,22 +393,23 @@ func (d *Document) CreateWithID(ctx context.Context, documentID string, document
 	}
 
 	// Enhance document with metadata
-	enhancedDoc := d.client.enhanceDocument(document)
-
-	// Convert document to JSON
-	docBytes, err := json.Marshal(enhancedDoc)
+	documentID, body, err := d.client.prepareDocument(documentID, document)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal document: %w", err)
+		return nil, err
 	}
 
+	pooled := newPooledBody(body)
+	defer pooled.release()
+
 	// Use the _create endpoint which fails if document already exists
 	req := esapi.CreateRequest{
-		Index:      d.index,
-		DocumentID: documentID,
-		Body:       io.NopCloser(bytes.NewReader(docBytes)),
+		Index:        d.index,
+		DocumentID:   documentID,
+		Body:         pooled.reader(),
+		RequireAlias: buildWriteOptions(options).requireAliasParam(),
 	}
 
-	res, err := req.Do(ctx, d.client.client)
+	res, err := req.Do(ctx, pooled.transport(d.client.client))
 	if err != nil {
 		d.client.config.Logger.Error("Failed to create document - index: %s, document_id: %s, error: %s", d.index, documentID, err.Error())
 		return nil, fmt.Errorf("failed to create document: %w", err)
@@ -393,105 +436,39 @@ func (d *Document) CreateWithID(ctx context.Context, documentID string, document
 	return &indexResponse, nil
 }
 
-// UpdateByQuery updates all documents matching a query using the _update_by_query API
-func (d *Document) UpdateByQuery(ctx context.Context, query map[string]any, script map[string]any) (map[string]any, error) {
-	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second) // Longer timeout for bulk operations
-		defer cancel()
-	}
-
-	// Build the request body
-	body := map[string]any{
-		"query": query,
-	}
-	if script != nil {
-		body["script"] = script
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal update by query body: %w", err)
-	}
-
-	req := esapi.UpdateByQueryRequest{
-		Index: []string{d.index},
-		Body:  io.NopCloser(bytes.NewReader(bodyBytes)),
+// Search performs a search on the document's index
+func (d *Document) Search(ctx context.Context, query map[string]any, options ...SearchOption) (*SearchResponse, error) {
+	idx := &Index{
+		client: d.client,
+		name:   d.index,
 	}
+	return idx.Search(ctx, query, options...)
+}
 
-	res, err := req.Do(ctx, d.client.client)
-	if err != nil {
-		d.client.config.Logger.Error("Failed to update by query - index: %s, error: %s", d.index, err.Error())
-		return nil, fmt.Errorf("failed to update by query: %w", err)
-	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			d.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
-		}
-	}()
-
-	if res.IsError() {
-		bodyBytes, _ := io.ReadAll(res.Body)
-		d.client.config.Logger.Error("Update by query failed - index: %s, status: %s, response: %s", d.index, res.Status(), string(bodyBytes))
-		return nil, fmt.Errorf("update by query failed: %s - %s", res.Status(), string(bodyBytes))
+// Count returns the number of documents in the document's index matching a query
+func (d *Document) Count(ctx context.Context, query map[string]any) (int64, error) {
+	idx := &Index{
+		client: d.client,
+		name:   d.index,
 	}
+	return idx.Count(ctx, query)
+}
 
-	var result map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode update by query response: %w", err)
+// Bulk returns a BulkIndexer for chaining bulk operations on the document's index
+func (d *Document) Bulk() *BulkIndexer {
+	return &BulkIndexer{
+		client:     d.client,
+		index:      d.index,
+		operations: make([]*BulkOperation, 0),
 	}
+}
 
-	d.client.config.Logger.Info("Update by query completed - index: %s", d.index)
-
-	return result, nil
+// UpdateByQuery updates all documents matching a query using the _update_by_query API
+func (d *Document) UpdateByQuery(ctx context.Context, query map[string]any, script map[string]any) (*ByQueryResult, error) {
+	return d.UpdateByQueryWithOptions(ctx, query, script, ByQueryOptions{})
 }
 
 // DeleteByQuery deletes all documents matching a query using the _delete_by_query API
-func (d *Document) DeleteByQuery(ctx context.Context, query map[string]any) (map[string]any, error) {
-	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second) // Longer timeout for bulk operations
-		defer cancel()
-	}
-
-	// Build the request body
-	body := map[string]any{
-		"query": query,
-	}
-
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal delete by query body: %w", err)
-	}
-
-	req := esapi.DeleteByQueryRequest{
-		Index: []string{d.index},
-		Body:  io.NopCloser(bytes.NewReader(bodyBytes)),
-	}
-
-	res, err := req.Do(ctx, d.client.client)
-	if err != nil {
-		d.client.config.Logger.Error("Failed to delete by query - index: %s, error: %s", d.index, err.Error())
-		return nil, fmt.Errorf("failed to delete by query: %w", err)
-	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			d.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
-		}
-	}()
-
-	if res.IsError() {
-		bodyBytes, _ := io.ReadAll(res.Body)
-		d.client.config.Logger.Error("Delete by query failed - index: %s, status: %s, response: %s", d.index, res.Status(), string(bodyBytes))
-		return nil, fmt.Errorf("delete by query failed: %s - %s", res.Status(), string(bodyBytes))
-	}
-
-	var result map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode delete by query response: %w", err)
-	}
-
-	d.client.config.Logger.Info("Delete by query completed - index: %s", d.index)
-
-	return result, nil
+func (d *Document) DeleteByQuery(ctx context.Context, query map[string]any) (*ByQueryResult, error) {
+	return d.DeleteByQueryWithOptions(ctx, query, ByQueryOptions{})
 }
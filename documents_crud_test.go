@@ -0,0 +1,410 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBuildPartialUpdate(t *testing.T) {
+	type productUpdate struct {
+		Price float64 `json:"price"`
+		Stock int     `json:"stock,omitempty"`
+	}
+
+	client := &Client{config: &Config{}}
+
+	body, err := client.buildPartialUpdate(productUpdate{Price: 9.99})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	partial := body["doc"].(map[string]any)
+	if partial["price"] != 9.99 {
+		t.Errorf("Expected struct fields to be converted, got %v", partial)
+	}
+	if _, exists := partial["stock"]; exists {
+		t.Error("Expected omitempty struct tags to be honored")
+	}
+	if partial["updated_at"] == nil {
+		t.Error("Expected updated_at to be injected by default")
+	}
+
+	original := map[string]any{"price": 5}
+	if _, err := client.buildPartialUpdate(original); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, exists := original["updated_at"]; exists {
+		t.Error("Expected the caller's map not to be modified")
+	}
+
+	client.config.SkipUpdateTimestamp = true
+	body, err = client.buildPartialUpdate(original)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, exists := body["doc"].(map[string]any)["updated_at"]; exists {
+		t.Error("Expected no updated_at when SkipUpdateTimestamp is set")
+	}
+
+	if _, err := client.buildPartialUpdate([]string{"not", "an", "object"}); err == nil {
+		t.Error("Expected error for a non-object document")
+	}
+}
+
+func TestDecodeUpdatedSource(t *testing.T) {
+	type product struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+
+	raw := `{"_index": "products", "_id": "1", "result": "updated",
+		"get": {"found": true, "_source": {"name": "widget", "price": 9.99}}}`
+
+	var response UpdateResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode update response: %v", err)
+	}
+
+	doc, err := decodeUpdatedSource[product](&response)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Name != "widget" || doc.Price != 9.99 {
+		t.Errorf("Unexpected updated document: %+v", doc)
+	}
+
+	if _, err := decodeUpdatedSource[product](&UpdateResponse{ID: "1"}); err == nil {
+		t.Error("Expected error when the response has no _source")
+	}
+
+	if opts := buildUpdateOptions([]UpdateOption{WithUpdateSource(true)}); !opts.source {
+		t.Error("Expected WithUpdateSource(true) to request the source")
+	}
+}
+
+func TestDocumentValidator(t *testing.T) {
+	client := &Client{config: &Config{
+		DocumentValidator: func(document any) error {
+			if doc, ok := document.(map[string]any); ok && doc["name"] == nil {
+				return errors.New("name is required")
+			}
+			return nil
+		},
+	}}
+
+	if _, _, err := client.prepareDocument("1", map[string]any{"name": "widget"}); err != nil {
+		t.Errorf("Expected valid document to pass, got %v", err)
+	}
+
+	_, _, err := client.prepareDocument("1", map[string]any{"price": 5})
+	if !errors.Is(err, ErrInvalidDocument) {
+		t.Fatalf("Expected ErrInvalidDocument, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "name is required") {
+		t.Errorf("Expected the validator's message in the error, got %q", err.Error())
+	}
+
+	bulk := &BulkResource{client: client}
+	err = bulk.writeBody(io.Discard, []*BulkOperation{
+		bulk.Index("products", "1", map[string]any{"name": "widget"}),
+		bulk.Index("products", "2", map[string]any{"price": 5}),
+	})
+	if !errors.Is(err, ErrInvalidDocument) {
+		t.Fatalf("Expected ErrInvalidDocument from bulk, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "bulk operation 1") {
+		t.Errorf("Expected the failing operation to be identified, got %q", err.Error())
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 1, "hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	client.config.ReadOnly = true
+
+	ctx := context.Background()
+	writes := map[string]func() error{
+		"index": func() error {
+			_, err := client.Documents().Create(ctx, "products", map[string]any{"name": "phone"})
+			return err
+		},
+		"update": func() error {
+			_, err := client.Documents().Update(ctx, "products", "1", map[string]any{"price": 10})
+			return err
+		},
+		"delete": func() error {
+			_, err := client.Documents().Delete(ctx, "products", "1")
+			return err
+		},
+		"bulk": func() error {
+			_, err := client.Documents().DeleteMany(ctx, "products", []string{"1"})
+			return err
+		},
+		"create index": func() error {
+			_, err := client.Indices().Create(ctx, "products", nil)
+			return err
+		},
+		"delete index": func() error {
+			return client.Indices().Delete(ctx, "products")
+		},
+		"reindex": func() error {
+			_, err := client.Indices().ReindexWithOptions(ctx, "products", "products-v2", ReindexOptions{})
+			return err
+		},
+		"put script": func() error {
+			return client.Documents().PutScript(ctx, "boost", "painless", "doc['price'].value * 2")
+		},
+		"delete script": func() error {
+			return client.Documents().DeleteScript(ctx, "boost")
+		},
+		"force merge": func() error {
+			return client.Indices().Get("products").ForceMerge(ctx, 1)
+		},
+		"raw delete": func() error {
+			_, err := client.DoRequest(ctx, http.MethodDelete, "/products", nil)
+			return err
+		},
+		"raw index": func() error {
+			_, err := client.DoRequest(ctx, http.MethodPost, "/products/_doc/_search", strings.NewReader(`{"name": "phone"}`))
+			return err
+		},
+	}
+	for name, write := range writes {
+		if err := write(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Expected %s to fail with ErrReadOnly, got %v", name, err)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no requests to reach the server, got %d", got)
+	}
+
+	if _, err := client.Indices().Get("products").Search(ctx, map[string]any{"match_all": map[string]any{}}); err != nil {
+		t.Errorf("Expected search to be allowed, got %v", err)
+	}
+	if _, err := client.DoRequest(ctx, http.MethodPost, "/products/_search", strings.NewReader(`{}`)); err != nil {
+		t.Errorf("Expected a raw search to be allowed, got %v", err)
+	}
+	if _, err := client.DoRequest(ctx, http.MethodGet, "/_cat/indices", nil); err != nil {
+		t.Errorf("Expected a raw GET to be allowed, got %v", err)
+	}
+}
+
+func TestDocumentsScoped(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_search"):
+			_, _ = w.Write([]byte(`{"took": 1, "hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"_index": "products", "_id": "1", "found": true, "_source": {"name": "phone"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"_index": "products", "_id": "1", "result": "created"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	products := client.Documents().Scoped("products")
+
+	ctx := context.Background()
+	if products.Name() != "products" {
+		t.Errorf("Expected scoped index products, got %s", products.Name())
+	}
+	if bulk := client.Documents().ForIndex("products"); bulk.index != "products" {
+		t.Errorf("Expected ForIndex to keep returning a bulk builder for products, got index %s", bulk.index)
+	}
+	if _, err := products.Create(ctx, map[string]any{"name": "phone"}); err != nil {
+		t.Fatalf("Unexpected create error: %v", err)
+	}
+	if _, err := products.Get(ctx, "1"); err != nil {
+		t.Fatalf("Unexpected get error: %v", err)
+	}
+	if _, err := products.Search(ctx, map[string]any{"match_all": map[string]any{}}); err != nil {
+		t.Fatalf("Unexpected search error: %v", err)
+	}
+
+	expected := []string{"POST /products/_doc", "GET /products/_doc/1", "POST /products/_search"}
+	if strings.Join(paths, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected requests %v, got %v", expected, paths)
+	}
+}
+
+// countingCodec wraps JSONCodec and counts calls, to check which paths use the configured codec
+type countingCodec struct {
+	JSONCodec
+	marshals   atomic.Int32
+	unmarshals atomic.Int32
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals.Add(1)
+	return c.JSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals.Add(1)
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+func TestWithCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/_search") {
+			_, _ = w.Write([]byte(`{"took": 1, "hits": {"total": {"value": 1, "relation": "eq"},
+				"hits": [{"_index": "products", "_id": "1", "_source": {"name": "phone"}}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"took": 1, "errors": false, "items": [{"index": {"_id": "1", "status": 201}}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	codec := &countingCodec{}
+	client.config.Codec = codec
+
+	ctx := context.Background()
+	if _, err := client.Documents().Bulk("products").Index("1", map[string]any{"name": "phone"}).Do(ctx); err != nil {
+		t.Fatalf("Unexpected bulk error: %v", err)
+	}
+	if codec.marshals.Load() == 0 {
+		t.Error("Expected the bulk body to be built with the configured codec")
+	}
+
+	marshals := codec.marshals.Load()
+	if _, err := client.Documents().Update(ctx, "products", "1", map[string]any{"price": 10}); err != nil {
+		t.Fatalf("Unexpected update error: %v", err)
+	}
+	if codec.marshals.Load() == marshals {
+		t.Error("Expected the update body to be built with the configured codec")
+	}
+
+	unmarshals := codec.unmarshals.Load()
+	if _, err := client.Documents().Scoped("products").Search(ctx, map[string]any{"match_all": map[string]any{}}); err != nil {
+		t.Fatalf("Unexpected search error: %v", err)
+	}
+	if codec.unmarshals.Load() == unmarshals {
+		t.Error("Expected the search response to be decoded with the configured codec")
+	}
+}
+
+func TestUpdateWithScriptRetryOnConflict(t *testing.T) {
+	var requestPath, retryOnConflict string
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		retryOnConflict = r.URL.Query().Get("retry_on_conflict")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"_index": "stats", "_id": "page-1", "_version": 7, "result": "updated"}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	script := map[string]any{"source": "ctx._source.views += params.n", "params": map[string]any{"n": 1}}
+	response, err := client.Documents().UpdateWithScript(context.Background(), "stats", "page-1", script, WithRetryOnConflict(5))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Result != "updated" {
+		t.Errorf("Expected result updated, got %s", response.Result)
+	}
+	if requestPath != "/stats/_update/page-1" || retryOnConflict != "5" {
+		t.Errorf("Expected retry_on_conflict=5 on /stats/_update/page-1, got %s retry_on_conflict=%q", requestPath, retryOnConflict)
+	}
+	if _, ok := body["script"]; !ok {
+		t.Errorf("Expected a script body, got %v", body)
+	}
+
+	if _, err := client.Documents().UpdateWithScript(context.Background(), "stats", "page-1", script, WithRetryOnConflict(-1)); err == nil {
+		t.Error("Expected a negative retry_on_conflict to be rejected")
+	}
+
+	indexer := client.Documents().Bulk("stats").UpdateWithScript("page-2", script, WithRetryOnConflict(3))
+	var bulkBody strings.Builder
+	bulk := &BulkResource{client: client}
+	if err := bulk.writeBody(&bulkBody, indexer.operations); err != nil {
+		t.Fatalf("Failed to build bulk body: %v", err)
+	}
+	if !strings.Contains(bulkBody.String(), `"retry_on_conflict":3`) {
+		t.Errorf("Expected retry_on_conflict on the bulk action line, got %s", bulkBody.String())
+	}
+}
+
+func TestWithRequireAlias(t *testing.T) {
+	var paths, requireAlias []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		requireAlias = append(requireAlias, r.URL.Query().Get("require_alias"))
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"_index": "logs-000001", "_id": "1", "result": "created"}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	document := map[string]any{"message": "hello"}
+
+	if _, err := client.Documents().Index(context.Background(), "logs", "1", document, WithRequireAlias(true)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Documents().CreateWithID(context.Background(), "logs", "2", document, WithRequireAlias(true)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Documents().Index(context.Background(), "logs", "3", document); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requireAlias[0] != "true" || requireAlias[1] != "true" || !strings.HasPrefix(paths[1], "/logs/_create/") {
+		t.Errorf("Expected require_alias=true on index and create, got %v on %v", requireAlias, paths)
+	}
+	if requireAlias[2] != "" {
+		t.Errorf("Expected require_alias to be omitted by default, got %q", requireAlias[2])
+	}
+}
+
+func TestPrepareDocumentNumericID(t *testing.T) {
+	type product struct {
+		ID   int64  `json:"_id"`
+		Name string `json:"name"`
+	}
+	client := &Client{config: &Config{IDMode: IDModeCustom, SkipUpdateTimestamp: true}}
+
+	id, body, err := client.prepareDocument("", product{ID: 12345678901, Name: "widget"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	putBuffer(body)
+	if id != "12345678901" {
+		t.Errorf("Expected the numeric _id to be kept as \"12345678901\", got %q", id)
+	}
+
+	if _, _, err := client.prepareDocument("", map[string]any{"_id": float64(1 << 60)}); err == nil {
+		t.Error("Expected an _id that float64 can't hold exactly to be rejected")
+	}
+	if _, _, err := client.prepareDocument("", map[string]any{"_id": true}); err == nil {
+		t.Error("Expected a boolean _id to be rejected")
+	}
+}
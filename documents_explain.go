@@ -0,0 +1,136 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// Explanation is a node of the score explanation tree returned by the explain API
+type Explanation struct {
+	Value       float64       `json:"value"`
+	Description string        `json:"description"`
+	Details     []Explanation `json:"details,omitempty"`
+}
+
+// ExplainResponse represents the response of the explain API
+type ExplainResponse struct {
+	Index       string       `json:"_index"`
+	ID          string       `json:"_id"`
+	Matched     bool         `json:"matched"`
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+// Reason returns a short, human-readable summary of why the document matched or not,
+// built from the leaves of the explanation tree
+func (r *ExplainResponse) Reason() string {
+	if r.Explanation == nil {
+		if r.Matched {
+			return "document matches the query"
+		}
+		return "document does not match the query"
+	}
+
+	var leaves []string
+	collectExplanationLeaves(*r.Explanation, &leaves)
+	if len(leaves) == 0 {
+		return r.Explanation.Description
+	}
+	return strings.Join(leaves, "; ")
+}
+
+// collectExplanationLeaves appends the descriptions of the explanation's leaf nodes
+func collectExplanationLeaves(explanation Explanation, leaves *[]string) {
+	if len(explanation.Details) == 0 {
+		if explanation.Description != "" {
+			*leaves = append(*leaves, explanation.Description)
+		}
+		return
+	}
+	for _, detail := range explanation.Details {
+		collectExplanationLeaves(detail, leaves)
+	}
+}
+
+// Explain explains how a document scores against a query builder, or why it doesn't match
+func (s *DocumentsService) Explain(ctx context.Context, indexName, documentID string, queryBuilder *query.Builder) (*ExplainResponse, error) {
+	searchResource := &SearchResource{
+		client: s.client,
+	}
+	return searchResource.Explain(ctx, indexName, documentID, queryBuilder.Build())
+}
+
+// ExplainMatch reports whether a document matches a query builder and, if not, the reason why.
+// It answers "why isn't this document showing up in search?" without reading the raw explanation.
+func (s *DocumentsService) ExplainMatch(ctx context.Context, indexName, documentID string, queryBuilder *query.Builder) (bool, string, error) {
+	response, err := s.Explain(ctx, indexName, documentID, queryBuilder)
+	if err != nil {
+		return false, "", err
+	}
+	return response.Matched, response.Reason(), nil
+}
+
+// Explain runs the explain API for a document and query
+func (sr *SearchResource) Explain(ctx context.Context, indexName, documentID string, query map[string]any) (*ExplainResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	bodyBytes, err := json.Marshal(map[string]any{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal explain query: %w", err)
+	}
+
+	req := esapi.ExplainRequest{
+		Index:      indexName,
+		DocumentID: documentID,
+		Body:       bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, sr.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("explain request failed: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	// A missing document is reported as 404 with matched=false; a missing index is an error
+	if res.StatusCode == 404 {
+		body, _ := io.ReadAll(res.Body)
+		if strings.Contains(string(body), "index_not_found_exception") {
+			return nil, fmt.Errorf("explain failed for document '%s' in index '%s': %s - %s", documentID, indexName, res.Status(), string(body))
+		}
+		return &ExplainResponse{
+			Index:   indexName,
+			ID:      documentID,
+			Matched: false,
+			Explanation: &Explanation{
+				Description: "document not found",
+			},
+		}, nil
+	}
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("explain failed for document '%s' in index '%s': %s - %s", documentID, indexName, res.Status(), string(body))
+	}
+
+	var response ExplainResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode explain response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,97 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// DocRef references a document by index and ID
+type DocRef struct {
+	Index   string `json:"_index,omitempty"`
+	ID      string `json:"_id"`
+	Routing string `json:"routing,omitempty"` // Required for documents indexed with a custom routing value
+}
+
+// MultiGetDoc is the result for one DocRef of a multi-get request
+type MultiGetDoc struct {
+	Index   string         `json:"_index"`
+	ID      string         `json:"_id"`
+	Found   bool           `json:"found"`
+	Version int64          `json:"_version,omitempty"`
+	Source  map[string]any `json:"_source,omitempty"`
+	Error   *ErrorCause    `json:"error,omitempty"` // Set when this document could not be fetched, e.g. its index is missing
+}
+
+// MultiGetDocs retrieves documents spread across several indices in a single _mget round trip.
+// Results are returned in the order of refs, including documents that were not found.
+func (s *DocumentsService) MultiGetDocs(ctx context.Context, refs []DocRef) ([]MultiGetDoc, error) {
+	doc := &Document{
+		client: s.client,
+	}
+	return doc.GetRefs(ctx, refs)
+}
+
+// GetRefs retrieves documents by reference; refs without an index use the document's index
+func (d *Document) GetRefs(ctx context.Context, refs []DocRef) ([]MultiGetDoc, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	if len(refs) == 0 {
+		return []MultiGetDoc{}, nil
+	}
+
+	docs := make([]DocRef, len(refs))
+	for i, ref := range refs {
+		if ref.Index == "" {
+			ref.Index = d.index
+		}
+		if ref.Index == "" {
+			return nil, fmt.Errorf("document reference %d (id '%s') has no index", i, ref.ID)
+		}
+		docs[i] = ref
+	}
+
+	bodyBytes, err := json.Marshal(map[string]any{"docs": docs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mget request: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Body: bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, d.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute mget request: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			d.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("mget request failed: %s - %s", res.Status(), string(body))
+	}
+
+	var mgetResponse struct {
+		Docs []MultiGetDoc `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&mgetResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode mget response: %w", err)
+	}
+
+	d.client.config.Logger.Debug("Documents retrieved by reference - requested: %d, returned: %d", len(refs), len(mgetResponse.Docs))
+
+	return mgetResponse.Docs, nil
+}
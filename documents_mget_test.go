@@ -0,0 +1,58 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMultiGetDocs(t *testing.T) {
+	var requested struct {
+		Docs []DocRef `json:"docs"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/_mget" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&requested)
+		_, _ = w.Write([]byte(`{"docs": [
+			{"_index": "orders", "_id": "o-1", "_version": 2, "found": true, "_source": {"total": 10}},
+			{"_index": "comments", "_id": "c-9", "found": false},
+			{"_index": "missing", "_id": "x", "error": {"type": "index_not_found_exception", "reason": "no such index [missing]"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	refs := []DocRef{{Index: "orders", ID: "o-1"}, {Index: "comments", ID: "c-9"}, {Index: "missing", ID: "x"}}
+	docs, err := client.Documents().MultiGetDocs(context.Background(), refs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(requested.Docs) != 3 || requested.Docs[1].Index != "comments" || requested.Docs[1].ID != "c-9" {
+		t.Errorf("Expected per-document indices in the request, got %+v", requested.Docs)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(docs))
+	}
+	if !docs[0].Found || docs[0].Source["total"] != float64(10) || docs[0].Version != 2 {
+		t.Errorf("Unexpected first result: %+v", docs[0])
+	}
+	if docs[1].Found || docs[1].Index != "comments" {
+		t.Errorf("Expected second document to be reported as not found, got %+v", docs[1])
+	}
+	if docs[2].Error == nil || docs[2].Error.Type != "index_not_found_exception" {
+		t.Errorf("Expected per-document error on the third result, got %+v", docs[2])
+	}
+
+	if _, err := client.Documents().MultiGetDocs(context.Background(), []DocRef{{ID: "no-index"}}); err == nil {
+		t.Error("Expected an error for a reference without an index")
+	}
+}
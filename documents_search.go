@@ -2,7 +2,9 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cloudresty/go-elastic/query"
@@ -38,7 +40,43 @@ func (t *TypedDocuments[T]) Search(ctx context.Context, queryBuilder *query.Buil
 	}
 
 	// Convert to typed result
-	return ConvertSearchResponse[T](response)
+	return convertSearchResponse[T](t.service.client.codec(), response)
+}
+
+// SearchRaw performs a search like Search but keeps each hit source as the raw JSON bytes of the
+// response, for indices that hold several document types told apart by a discriminator field.
+// Sources are never decoded into maps, so large integers and key order are preserved. Decode each
+// hit into the right struct with DecodeSource and the client's Codec.
+func (t *TypedDocuments[T]) SearchRaw(ctx context.Context, queryBuilder *query.Builder, options ...SearchOption) (*SearchResult[json.RawMessage], error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	client := t.service.client
+	searchResource := &SearchResource{
+		client: client,
+	}
+
+	res, indices, err := searchResource.sendSearch(ctx, queryBuilder.Build(), options)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	var result SearchResult[json.RawMessage]
+	if err := client.decodeBody(res.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	client.config.Logger.Debug("Raw search completed successfully - indices: %s, hits: %d, total: %d, took: %d", strings.Join(indices, ","), len(result.Hits.Hits), result.Hits.Total.Value, result.Took)
+
+	return &result, nil
 }
 
 // Scroll creates a new typed search iterator for paginated results using the scroll API
@@ -60,10 +98,11 @@ func (t *TypedDocuments[T]) Scroll(ctx context.Context, queryBuilder *query.Buil
 		scrollTime:   scrollTime,
 		currentIndex: -1, // Start before first element
 		totalHits:    int64(initialResponse.Hits.Total.Value),
+		aggregations: initialResponse.Aggregations,
 	}
 
 	// Convert initial hits to typed hits
-	typedResult, err := ConvertSearchResponse[T](initialResponse)
+	typedResult, err := convertSearchResponse[T](t.service.client.codec(), initialResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert initial scroll response: %w", err)
 	}
@@ -72,6 +111,44 @@ func (t *TypedDocuments[T]) Scroll(ctx context.Context, queryBuilder *query.Buil
 	return iterator, nil
 }
 
+// ScrollSlice creates a typed search iterator over one slice of a sliced scroll
+// Run one ScrollSlice per worker with the same maxSlices and distinct sliceIDs (0..maxSlices-1)
+// to export disjoint portions of the result set in parallel
+func (t *TypedDocuments[T]) ScrollSlice(ctx context.Context, queryBuilder *query.Builder, sliceID, maxSlices int, scrollTime time.Duration, options ...SearchOption) (*TypedSearchIterator[T], error) {
+	if maxSlices < 2 {
+		return nil, fmt.Errorf("max slices must be greater than 1, got %d", maxSlices)
+	}
+	if sliceID < 0 || sliceID >= maxSlices {
+		return nil, fmt.Errorf("slice id must be between 0 and %d, got %d", maxSlices-1, sliceID)
+	}
+
+	sliceOptions := make([]SearchOption, 0, len(options)+1)
+	sliceOptions = append(sliceOptions, options...)
+	sliceOptions = append(sliceOptions, WithSlice(sliceID, maxSlices))
+
+	return t.Scroll(ctx, queryBuilder, scrollTime, sliceOptions...)
+}
+
+// ScrollWithLimit counts the documents matching the query first and only starts the scroll when
+// there are at most maxDocs of them, returning a *ScrollLimitError otherwise. Use it in batch jobs
+// where a too-broad query would otherwise export the whole index.
+func (t *TypedDocuments[T]) ScrollWithLimit(ctx context.Context, queryBuilder *query.Builder, maxDocs int, scrollTime time.Duration, options ...SearchOption) (*TypedSearchIterator[T], error) {
+	if maxDocs < 0 {
+		return nil, fmt.Errorf("max docs cannot be negative, got %d", maxDocs)
+	}
+
+	count, err := t.service.Count(ctx, queryBuilder, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents before scrolling: %w", err)
+	}
+	if count > int64(maxDocs) {
+		t.service.client.config.Logger.Warn("Scroll refused - matching documents: %d, limit: %d", count, maxDocs)
+		return nil, &ScrollLimitError{Count: count, MaxDocs: int64(maxDocs)}
+	}
+
+	return t.Scroll(ctx, queryBuilder, scrollTime, options...)
+}
+
 // Count returns the count of documents matching a query builder
 func (s *DocumentsService) Count(ctx context.Context, queryBuilder *query.Builder, options ...SearchOption) (int64, error) {
 	searchResource := &SearchResource{
@@ -79,3 +156,11 @@ func (s *DocumentsService) Count(ctx context.Context, queryBuilder *query.Builde
 	}
 	return searchResource.Count(ctx, queryBuilder.Build(), options...)
 }
+
+// CountAll returns the total number of documents in the given indices (or all indices if none specified)
+func (s *DocumentsService) CountAll(ctx context.Context, indices ...string) (int64, error) {
+	searchResource := &SearchResource{
+		client: s.client,
+	}
+	return searchResource.CountAll(ctx, indices...)
+}
@@ -0,0 +1,86 @@
+package elastic
+
+import (
+	"context"
+	"fmt"
+)
+
+// distinctValuesPageSize is the number of composite buckets DistinctValues requests per page
+const distinctValuesPageSize = 1000
+
+// distinctValuesAggregation is the name of the composite aggregation DistinctValues pages through
+const distinctValuesAggregation = "distinct_values"
+
+// DistinctValue is a distinct value of a field together with the number of documents holding it
+type DistinctValue struct {
+	Value    any   `json:"value"`
+	DocCount int64 `json:"doc_count"`
+}
+
+// compositeAggregationPage is one page of a composite aggregation response
+type compositeAggregationPage struct {
+	AfterKey map[string]any `json:"after_key"`
+	Buckets  []struct {
+		Key      map[string]any `json:"key"`
+		DocCount int64          `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+// DistinctValues returns every distinct value of field with its document count, in ascending
+// order, by paging a composite terms aggregation until it is exhausted. Unlike a terms
+// aggregation it is exact and not limited by size, so it suits high-cardinality keyword fields.
+// Options such as WithIndices and WithSearchRouting apply to every page; hits are never fetched.
+func (t *TypedDocuments[T]) DistinctValues(ctx context.Context, field string, options ...SearchOption) ([]DistinctValue, error) {
+	if ctx == nil {
+		// No overall timeout: enumerating a high-cardinality field may take many pages
+		ctx = context.Background()
+	}
+
+	searchResource := &SearchResource{
+		client: t.service.client,
+	}
+
+	var values []DistinctValue
+	var afterKey map[string]any
+	for {
+		composite := map[string]any{
+			"size": distinctValuesPageSize,
+			"sources": []any{
+				map[string]any{"value": map[string]any{"terms": map[string]any{"field": field}}},
+			},
+		}
+		if afterKey != nil {
+			composite["after"] = afterKey
+		}
+
+		pageOptions := make([]SearchOption, 0, len(options)+2)
+		pageOptions = append(pageOptions, options...)
+		pageOptions = append(pageOptions,
+			WithSize(0),
+			WithAggregations(map[string]any{distinctValuesAggregation: map[string]any{"composite": composite}}),
+		)
+
+		response, err := searchResource.Search(ctx, MatchAllQuery(), pageOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch distinct values of field '%s': %w", field, err)
+		}
+
+		var page compositeAggregationPage
+		if err := decodeAggregation(response.Aggregations, distinctValuesAggregation, &page); err != nil {
+			return nil, err
+		}
+
+		for _, bucket := range page.Buckets {
+			values = append(values, DistinctValue{Value: bucket.Key["value"], DocCount: bucket.DocCount})
+		}
+
+		if len(page.Buckets) == 0 || page.AfterKey == nil {
+			break
+		}
+		afterKey = page.AfterKey
+	}
+
+	t.service.client.config.Logger.Debug("Distinct values collected - field: %s, values: %d", field, len(values))
+
+	return values, nil
+}
@@ -1,7 +1,11 @@
 package elastic
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/cloudresty/go-elastic/query"
 )
 
 // Common search helpers
@@ -110,14 +114,10 @@ func TermsAggregation(field string, size int) map[string]any {
 	}
 }
 
-// DateHistogramAggregation creates a date histogram aggregation
+// DateHistogramAggregation creates a date histogram aggregation, sending the interval as
+// calendar_interval or fixed_interval (see NewDateHistogramAggregation)
 func DateHistogramAggregation(field, interval string) map[string]any {
-	return map[string]any{
-		"date_histogram": map[string]any{
-			"field":    field,
-			"interval": interval,
-		},
-	}
+	return NewDateHistogramAggregation(field, interval).Build()
 }
 
 // StatsAggregation creates a stats aggregation
@@ -145,6 +145,11 @@ func BuildSearchQuery(query map[string]any, options ...SearchOption) map[string]
 	return searchQuery
 }
 
+// CanonicalJSON returns the canonical JSON of a query or search body, see query.CanonicalJSON
+func CanonicalJSON(v any) ([]byte, error) {
+	return query.CanonicalJSON(v)
+}
+
 // SearchOption represents a search query option
 type SearchOption func(map[string]any)
 
@@ -222,13 +227,102 @@ func WithSource(includes ...string) SearchOption {
 	}
 }
 
-// WithTimeout sets the timeout parameter
+// WithFields requests the values of the fields matching the given patterns (e.g. "user.*") in each
+// hit's Fields, read from the mapping rather than the raw _source: dates and numbers are formatted
+// consistently and runtime fields are included. Can be called multiple times and combined with WithFieldFormat.
+func WithFields(patterns ...string) SearchOption {
+	return func(query map[string]any) {
+		appendSearchFields(query, "fields", patterns)
+	}
+}
+
+// WithFieldFormat requests a field in each hit's Fields formatted per request, e.g.
+// WithFieldFormat("created_at", "yyyy-MM-dd") or WithFieldFormat("created_at", "epoch_millis")
+func WithFieldFormat(field, format string) SearchOption {
+	return func(query map[string]any) {
+		appendSearchFields(query, "fields", []any{map[string]any{"field": field, "format": format}})
+	}
+}
+
+// WithDocValueFields requests the values of the given fields from doc values in each hit's Fields,
+// which avoids loading _source; fields without doc values (e.g. text) cannot be requested this way.
+// Can be called multiple times.
+func WithDocValueFields(fields ...string) SearchOption {
+	return func(query map[string]any) {
+		appendSearchFields(query, "docvalue_fields", fields)
+	}
+}
+
+// appendSearchFields appends field entries (names or field/format objects) to the given body key
+func appendSearchFields[E any](query map[string]any, key string, entries []E) {
+	existing, _ := query[key].([]any)
+	for _, entry := range entries {
+		existing = append(existing, entry)
+	}
+	query[key] = existing
+}
+
+// WithTimeout sets the server-side search timeout (e.g. "2s"), overriding Config.SearchTimeout.
+// It is best-effort: shards stop collecting when it expires and the partial hits come back with
+// timed_out set. Bound the overall wait, including network time, with the context deadline instead.
 func WithTimeout(timeout string) SearchOption {
 	return func(query map[string]any) {
 		query["timeout"] = timeout
 	}
 }
 
+// WithSlice sets the slice parameter for sliced scroll searches
+func WithSlice(id, max int) SearchOption {
+	return func(query map[string]any) {
+		query["slice"] = map[string]any{
+			"id":  id,
+			"max": max,
+		}
+	}
+}
+
+// WithSearchAfter sets the sort values of the last hit of the previous page, for deep pagination
+// beyond index.max_result_window (requires WithSort)
+func WithSearchAfter(values ...any) SearchOption {
+	return func(query map[string]any) {
+		query["search_after"] = values
+	}
+}
+
+// WithPreference sets the search preference (e.g. "_local", "_primary" or a custom string such as a
+// session ID) so repeated searches hit the same shard copies for cache locality and consistent results
+func WithPreference(preference string) SearchOption {
+	return func(query map[string]any) {
+		query["preference"] = preference
+	}
+}
+
+// WithSearchRouting limits the search to the shards holding the given routing values
+func WithSearchRouting(routing ...string) SearchOption {
+	return func(query map[string]any) {
+		query["routing"] = routing
+	}
+}
+
+// WithTerminateAfter stops collecting after the given number of documents per shard. Counts and
+// hit totals become lower bounds, which makes "does any document match" or "are there more than N"
+// checks much cheaper than an exact count; SearchResponse.TerminatedEarly reports when it kicked in.
+func WithTerminateAfter(maxDocs int) SearchOption {
+	return func(query map[string]any) {
+		query["terminate_after"] = maxDocs
+	}
+}
+
+// WithCCSMinimizeRoundtrips sets whether a cross-cluster search coordinates each remote cluster's
+// search on that cluster (true, the Elasticsearch default) or fans out to every remote shard from
+// the local node (false). Scroll searches always use false. Check SearchResult.SkippedClusters or
+// PartialResults to detect remote clusters that did not contribute hits.
+func WithCCSMinimizeRoundtrips(minimize bool) SearchOption {
+	return func(query map[string]any) {
+		query["ccs_minimize_roundtrips"] = minimize
+	}
+}
+
 // Common filter builders
 
 // ByID creates a filter for finding by _id
@@ -241,11 +335,11 @@ func ByField(field string, value any) map[string]any {
 	return TermQuery(field, value)
 }
 
-// ByFields creates a filter for multiple fields (bool query with must clauses)
+// ByFields creates a filter for multiple fields (bool query with must clauses, ordered by field name)
 func ByFields(fields map[string]any) map[string]any {
 	query := BoolQuery()
-	for field, value := range fields {
-		query = WithMust(query, TermQuery(field, value))
+	for _, field := range sortedKeys(fields) {
+		query = WithMust(query, TermQuery(field, fields[field]))
 	}
 	return query
 }
@@ -261,10 +355,10 @@ func SetScript(fields map[string]any) map[string]any {
 	}
 }
 
-// IncScript creates a script for incrementing field values
+// IncScript creates a script for incrementing field values, ordered by field name
 func IncScript(fields map[string]any) map[string]any {
 	var statements []string
-	for field := range fields {
+	for _, field := range sortedKeys(fields) {
 		statements = append(statements, "ctx._source."+field+" += params."+field)
 	}
 	return map[string]any{
@@ -273,6 +367,16 @@ func IncScript(fields map[string]any) map[string]any {
 	}
 }
 
+// sortedKeys returns the keys of fields in ascending order, so generated queries are deterministic
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Common query builders (extending existing ones)
 
 // ExistsQuery creates an exists query
@@ -357,12 +461,16 @@ func SumAggregation(field string) map[string]any {
 	}
 }
 
-// CardinalityAggregation creates a cardinality aggregation
-func CardinalityAggregation(field string) map[string]any {
+// CardinalityAggregation creates a cardinality aggregation with an optional precision_threshold
+func CardinalityAggregation(field string, precisionThreshold ...int) map[string]any {
+	cardinality := map[string]any{
+		"field": field,
+	}
+	if len(precisionThreshold) > 0 && precisionThreshold[0] > 0 {
+		cardinality["precision_threshold"] = precisionThreshold[0]
+	}
 	return map[string]any{
-		"cardinality": map[string]any{
-			"field": field,
-		},
+		"cardinality": cardinality,
 	}
 }
 
@@ -480,7 +588,8 @@ func SimpleSearch(queryText string, fields []string, size int) map[string]any {
 	return BuildSearchQuery(query, WithSize(size))
 }
 
-// PaginatedSearch creates a paginated search query
+// PaginatedSearch creates a paginated search query. Pages beyond index.max_result_window are
+// rejected by Elasticsearch; use CheckedPaginatedSearch to catch them early.
 func PaginatedSearch(query map[string]any, page, pageSize int, sortField string, sortAsc bool) map[string]any {
 	from := (page - 1) * pageSize
 
@@ -498,3 +607,18 @@ func PaginatedSearch(query map[string]any, page, pageSize int, sortField string,
 		WithSort(sort),
 	)
 }
+
+// CheckedPaginatedSearch creates a paginated search query like PaginatedSearch, but returns a
+// *ResultWindowError when the requested page lies beyond maxResultWindow (DefaultMaxResultWindow when <= 0)
+func CheckedPaginatedSearch(query map[string]any, page, pageSize int, sortField string, sortAsc bool, maxResultWindow int) (map[string]any, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("page must be at least 1, got %d", page)
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	if err := CheckResultWindow((page-1)*pageSize, pageSize, maxResultWindow); err != nil {
+		return nil, err
+	}
+	return PaginatedSearch(query, page, pageSize, sortField, sortAsc), nil
+}
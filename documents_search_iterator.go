@@ -2,7 +2,6 @@ package elastic
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -27,6 +26,13 @@ func (si *SearchIterator) Next(ctx context.Context) bool {
 		return false
 	}
 
+	// Stop if the caller gave up, releasing the server-side scroll context
+	if ctx != nil && ctx.Err() != nil {
+		si.err = ctx.Err()
+		si.releaseScroll()
+		return false
+	}
+
 	// If we have more hits in the current batch, advance to next
 	if si.currentIndex < len(si.currentHits)-1 {
 		si.currentIndex++
@@ -43,6 +49,9 @@ func (si *SearchIterator) Next(ctx context.Context) bool {
 	// Need to fetch next batch
 	if err := si.fetchNextBatch(ctx); err != nil {
 		si.err = err
+		if ctx != nil && ctx.Err() != nil {
+			si.releaseScroll()
+		}
 		return false
 	}
 
@@ -72,12 +81,12 @@ func (si *SearchIterator) Scan(dest any) error {
 	currentHit := si.currentHits[si.currentIndex]
 
 	// Marshal the source back to JSON, then unmarshal to dest
-	sourceBytes, err := json.Marshal(currentHit.Source)
+	sourceBytes, err := si.client.codec().Marshal(currentHit.Source)
 	if err != nil {
 		return fmt.Errorf("failed to marshal document source: %w", err)
 	}
 
-	if err := json.Unmarshal(sourceBytes, dest); err != nil {
+	if err := si.client.codec().Unmarshal(sourceBytes, dest); err != nil {
 		return fmt.Errorf("failed to unmarshal document into destination: %w", err)
 	}
 
@@ -115,7 +124,8 @@ func (si *SearchIterator) ProcessedHits() int64 {
 	return si.processedHits
 }
 
-// Close cleans up the scroll context (called automatically when iteration completes)
+// Close cleans up the scroll context (called automatically when iteration completes or is cancelled)
+// It is safe to call Close multiple times
 func (si *SearchIterator) Close(ctx context.Context) error {
 	if si.scrollID != "" {
 		return si.clearScroll(ctx)
@@ -123,6 +133,15 @@ func (si *SearchIterator) Close(ctx context.Context) error {
 	return nil
 }
 
+// releaseScroll clears the scroll context with a detached context, since the
+// caller's context may already be cancelled when iteration stops
+func (si *SearchIterator) releaseScroll() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = si.clearScroll(ctx) // Failures are already logged by clearScroll
+}
+
 // fetchNextBatch retrieves the next batch of results using the scroll API
 func (si *SearchIterator) fetchNextBatch(ctx context.Context) error {
 	if ctx == nil {
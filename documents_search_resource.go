@@ -12,6 +12,14 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/esapi"
 )
 
+// DefaultMaxResultWindow is the default value of the index.max_result_window setting,
+// the largest from + size (or scroll batch size) Elasticsearch accepts per request
+const DefaultMaxResultWindow = 10000
+
+// defaultScrollSize is the scroll batch size used when neither WithSize nor
+// Config.DefaultScrollSize is set
+const defaultScrollSize = 1000
+
 // SearchResource provides search operations across indices
 type SearchResource struct {
 	client *Client
@@ -44,6 +52,51 @@ func extractIndicesFromOptions(options []SearchOption) []string {
 	return []string{"_all"}
 }
 
+// searchRequestParams holds search options that are sent as URL parameters rather than in the body
+type searchRequestParams struct {
+	preference            string
+	routing               []string
+	terminateAfter        *int
+	ccsMinimizeRoundtrips *bool
+}
+
+// extractSearchRequestParams removes URL-level parameters (preference, routing, terminate_after,
+// ccs_minimize_roundtrips) from the search body
+func extractSearchRequestParams(searchBody map[string]any) searchRequestParams {
+	var params searchRequestParams
+
+	if preference, ok := searchBody["preference"].(string); ok {
+		params.preference = preference
+	}
+	delete(searchBody, "preference")
+
+	if routing, ok := searchBody["routing"].([]string); ok {
+		params.routing = routing
+	}
+	delete(searchBody, "routing")
+
+	if terminateAfter, ok := searchBody["terminate_after"].(int); ok {
+		params.terminateAfter = &terminateAfter
+	}
+	delete(searchBody, "terminate_after")
+
+	if minimizeRoundtrips, ok := searchBody["ccs_minimize_roundtrips"].(bool); ok {
+		params.ccsMinimizeRoundtrips = &minimizeRoundtrips
+	}
+	delete(searchBody, "ccs_minimize_roundtrips")
+
+	return params
+}
+
+// searchRequestParamsFromOptions collects URL-level parameters from search options
+func searchRequestParamsFromOptions(options []SearchOption) searchRequestParams {
+	temp := make(map[string]any)
+	for _, option := range options {
+		option(temp)
+	}
+	return extractSearchRequestParams(temp)
+}
+
 // Scroll returns a SearchScroll resource for scroll operations
 func (sr *SearchResource) Scroll(options ...SearchOption) *SearchScroll {
 	return &SearchScroll{
@@ -59,47 +112,74 @@ func (sr *SearchResource) Search(ctx context.Context, query map[string]any, opti
 		defer cancel()
 	}
 
+	res, indices, err := sr.sendSearch(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	var searchResponse SearchResponse
+	if err := sr.client.decodeBody(res.Body, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	sr.client.config.Logger.Debug("Search completed successfully - indices: %s, hits: %d, total: %d, took: %d", strings.Join(indices, ","), len(searchResponse.Hits.Hits), int(searchResponse.Hits.Total.Value), searchResponse.Took)
+
+	return &searchResponse, nil
+}
+
+// sendSearch sends a search request and returns the successful response, whose body the caller
+// must close, together with the searched indices
+func (sr *SearchResource) sendSearch(ctx context.Context, query map[string]any, options []SearchOption) (*esapi.Response, []string, error) {
 	// Build search body using existing BuildSearchQuery function
 	searchBody := BuildSearchQuery(query, options...)
+	params := extractSearchRequestParams(searchBody)
+	sr.client.applySearchTimeout(searchBody)
+
+	// Reject deep pagination before Elasticsearch does when a result window is configured
+	if err := sr.client.checkResultWindow(searchBody); err != nil {
+		return nil, nil, err
+	}
 
-	bodyBytes, err := json.Marshal(searchBody)
+	bodyBytes, err := sr.client.codec().Marshal(searchBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal search query: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal search query: %w", err)
 	}
 
 	// Extract indices from options, default to "_all"
 	indices := extractIndicesFromOptions(options)
 
 	req := esapi.SearchRequest{
-		Index: indices,
-		Body:  bytes.NewReader(bodyBytes),
+		Index:                 indices,
+		Body:                  bytes.NewReader(bodyBytes),
+		Preference:            params.preference,
+		Routing:               params.routing,
+		TerminateAfter:        params.terminateAfter,
+		CcsMinimizeRoundtrips: params.ccsMinimizeRoundtrips,
 	}
 
 	res, err := req.Do(ctx, sr.client.client)
 	if err != nil {
 		sr.client.config.Logger.Error("Search failed - indices: %s, error: %s", strings.Join(indices, ","), err.Error())
-		return nil, fmt.Errorf("search request failed: %w", err)
+		return nil, nil, fmt.Errorf("search request failed: %w", err)
 	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
-		}
-	}()
 
 	if res.IsError() {
+		defer func() {
+			if err := res.Body.Close(); err != nil {
+				sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+			}
+		}()
 		bodyBytes, _ := io.ReadAll(res.Body)
 		sr.client.config.Logger.Error("Search failed - indices: %s, status: %s, response: %s", strings.Join(indices, ","), res.Status(), string(bodyBytes))
-		return nil, fmt.Errorf("search failed: %s - %s", res.Status(), string(bodyBytes))
+		return nil, nil, fmt.Errorf("search failed: %s - %s", res.Status(), string(bodyBytes))
 	}
 
-	var searchResponse SearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode search response: %w", err)
-	}
-
-	sr.client.config.Logger.Debug("Search completed successfully - indices: %s, hits: %d, total: %d, took: %d", strings.Join(indices, ","), len(searchResponse.Hits.Hits), int(searchResponse.Hits.Total.Value), searchResponse.Took)
-
-	return &searchResponse, nil
+	return res, indices, nil
 }
 
 // Count returns the number of documents matching the query
@@ -123,9 +203,13 @@ func (sr *SearchResource) Count(ctx context.Context, query map[string]any, optio
 
 	// Extract indices from options, default to "_all"
 	indices := extractIndicesFromOptions(options)
+	params := searchRequestParamsFromOptions(options)
 
 	req := esapi.CountRequest{
-		Index: indices,
+		Index:          indices,
+		Preference:     params.preference,
+		Routing:        params.routing,
+		TerminateAfter: params.terminateAfter,
 	}
 
 	if bodyBytes != nil {
@@ -162,6 +246,14 @@ func (sr *SearchResource) Count(ctx context.Context, query map[string]any, optio
 	return countResponse.Count, nil
 }
 
+// CountAll returns the total number of documents in the given indices (or all indices if none specified)
+func (sr *SearchResource) CountAll(ctx context.Context, indices ...string) (int64, error) {
+	if len(indices) == 0 {
+		return sr.Count(ctx, nil)
+	}
+	return sr.Count(ctx, nil, WithIndices(indices...))
+}
+
 // startScrollSearch initiates a scroll search and returns the initial response
 func (sr *SearchResource) startScrollSearch(ctx context.Context, query map[string]any, scrollTime time.Duration, options ...SearchOption) (*SearchResponse, error) {
 	if ctx == nil {
@@ -172,13 +264,15 @@ func (sr *SearchResource) startScrollSearch(ctx context.Context, query map[strin
 
 	// Build search body using existing BuildSearchQuery function
 	searchBody := BuildSearchQuery(query, options...)
+	params := extractSearchRequestParams(searchBody)
+	sr.client.applySearchTimeout(searchBody)
 
 	// Set default scroll size if not specified
-	if _, hasSize := searchBody["size"]; !hasSize {
-		searchBody["size"] = 1000
+	if err := sr.client.applyScrollSize(searchBody); err != nil {
+		return nil, err
 	}
 
-	bodyBytes, err := json.Marshal(searchBody)
+	bodyBytes, err := sr.client.codec().Marshal(searchBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal search query: %w", err)
 	}
@@ -187,9 +281,12 @@ func (sr *SearchResource) startScrollSearch(ctx context.Context, query map[strin
 	indices := extractIndicesFromOptions(options)
 
 	req := esapi.SearchRequest{
-		Index:  indices,
-		Body:   bytes.NewReader(bodyBytes),
-		Scroll: scrollTime,
+		Index:          indices,
+		Body:           bytes.NewReader(bodyBytes),
+		Scroll:         scrollTime,
+		Preference:     params.preference,
+		Routing:        params.routing,
+		TerminateAfter: params.terminateAfter,
 	}
 
 	res, err := req.Do(ctx, sr.client.client)
@@ -210,7 +307,7 @@ func (sr *SearchResource) startScrollSearch(ctx context.Context, query map[strin
 	}
 
 	var searchResponse SearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+	if err := sr.client.decodeBody(res.Body, &searchResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode scroll search response: %w", err)
 	}
 
@@ -218,3 +315,76 @@ func (sr *SearchResource) startScrollSearch(ctx context.Context, query map[strin
 
 	return &searchResponse, nil
 }
+
+// defaultSearchSize is the number of hits Elasticsearch returns when size is not set
+const defaultSearchSize = 10
+
+// maxResultWindow returns the configured max result window, falling back to DefaultMaxResultWindow
+// for checks that always apply, such as the scroll batch size
+func (c *Client) maxResultWindow() int {
+	if c.config != nil && c.config.MaxResultWindow > 0 {
+		return c.config.MaxResultWindow
+	}
+	return DefaultMaxResultWindow
+}
+
+// CheckResultWindow returns a *ResultWindowError when from + size exceeds maxResultWindow.
+// A maxResultWindow <= 0 uses DefaultMaxResultWindow.
+func CheckResultWindow(from, size, maxResultWindow int) error {
+	if maxResultWindow <= 0 {
+		maxResultWindow = DefaultMaxResultWindow
+	}
+	if from+size > maxResultWindow {
+		return &ResultWindowError{From: from, Size: size, MaxResultWindow: maxResultWindow}
+	}
+	return nil
+}
+
+// checkResultWindow validates the from/size of a search body before it is sent. It only applies
+// when Config.MaxResultWindow is set, since indices may raise index.max_result_window and the
+// limit is per index; otherwise Elasticsearch enforces it.
+func (c *Client) checkResultWindow(searchBody map[string]any) error {
+	if c.config == nil || c.config.MaxResultWindow <= 0 {
+		return nil
+	}
+	from, _ := searchBody["from"].(int)
+	size, ok := searchBody["size"].(int)
+	if !ok {
+		size = defaultSearchSize
+	}
+	return CheckResultWindow(from, size, c.config.MaxResultWindow)
+}
+
+// applySearchTimeout sets Config.SearchTimeout as the search body timeout unless the search sets its own
+func (c *Client) applySearchTimeout(searchBody map[string]any) {
+	if c.config.SearchTimeout <= 0 {
+		return
+	}
+	if _, hasTimeout := searchBody["timeout"]; hasTimeout {
+		return
+	}
+	searchBody["timeout"] = fmt.Sprintf("%dms", c.config.SearchTimeout.Milliseconds())
+}
+
+// applyScrollSize sets the scroll batch size on the search body when not specified
+// and validates that it stays within the default max_result_window
+func (c *Client) applyScrollSize(searchBody map[string]any) error {
+	if _, hasSize := searchBody["size"]; !hasSize {
+		size := c.config.DefaultScrollSize
+		if size <= 0 {
+			size = defaultScrollSize
+		}
+		searchBody["size"] = size
+	}
+
+	if size, ok := searchBody["size"].(int); ok {
+		if size <= 0 {
+			return fmt.Errorf("scroll size must be positive, got %d", size)
+		}
+		if maxWindow := c.maxResultWindow(); size > maxWindow {
+			return fmt.Errorf("scroll size %d exceeds index.max_result_window (%d); use a smaller batch size", size, maxWindow)
+		}
+	}
+
+	return nil
+}
@@ -26,10 +26,12 @@ func (ss *SearchScroll) Start(ctx context.Context, query map[string]any, scrollT
 
 	// Build search body using existing BuildSearchQuery function
 	searchBody := BuildSearchQuery(query, options...)
+	params := extractSearchRequestParams(searchBody)
+	ss.client.applySearchTimeout(searchBody)
 
 	// Set default scroll size if not specified
-	if _, hasSize := searchBody["size"]; !hasSize {
-		searchBody["size"] = 1000
+	if err := ss.client.applyScrollSize(searchBody); err != nil {
+		return nil, err
 	}
 
 	bodyBytes, err := json.Marshal(searchBody)
@@ -41,9 +43,12 @@ func (ss *SearchScroll) Start(ctx context.Context, query map[string]any, scrollT
 	indices := extractIndicesFromOptions(options)
 
 	req := esapi.SearchRequest{
-		Index:  indices,
-		Body:   bytes.NewReader(bodyBytes),
-		Scroll: scrollTime,
+		Index:          indices,
+		Body:           bytes.NewReader(bodyBytes),
+		Scroll:         scrollTime,
+		Preference:     params.preference,
+		Routing:        params.routing,
+		TerminateAfter: params.terminateAfter,
 	}
 
 	res, err := req.Do(ctx, ss.client.client)
@@ -62,7 +67,7 @@ func (ss *SearchScroll) Start(ctx context.Context, query map[string]any, scrollT
 	}
 
 	var searchResponse SearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+	if err := ss.client.decodeBody(res.Body, &searchResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode scroll search response: %w", err)
 	}
 
@@ -100,7 +105,7 @@ func (ss *SearchScroll) Continue(ctx context.Context, scrollID string, scrollTim
 	}
 
 	var searchResponse SearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+	if err := ss.client.decodeBody(res.Body, &searchResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode scroll continue response: %w", err)
 	}
 
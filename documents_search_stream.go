@@ -0,0 +1,158 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SearchStream runs a search from the given indices and passes each hit to onHit as it is decoded
+// from the response, instead of materializing the whole hit list; use it for large size values
+// such as a 10k-hit export. Returning an error from onHit stops decoding and is returned as is.
+// The returned response carries everything except the hits (total, aggregations, shards, ...).
+// Hits are always decoded with encoding/json, since the Codec interface cannot stream.
+func (s *DocumentsService) SearchStream(ctx context.Context, query map[string]any, onHit func(hit Hit) error, options ...SearchOption) (*SearchResponse, error) {
+	searchResource := &SearchResource{
+		client: s.client,
+	}
+	return searchResource.SearchStream(ctx, query, onHit, options...)
+}
+
+// SearchStream runs a search on the document's index and passes each hit to onHit as it is decoded
+func (d *Document) SearchStream(ctx context.Context, query map[string]any, onHit func(hit Hit) error, options ...SearchOption) (*SearchResponse, error) {
+	searchResource := &SearchResource{
+		client: d.client,
+	}
+	return searchResource.SearchStream(ctx, query, onHit, append(options, WithIndices(d.index))...)
+}
+
+// SearchStream performs a search and passes each hit to onHit as it is decoded from the response
+func (sr *SearchResource) SearchStream(ctx context.Context, query map[string]any, onHit func(hit Hit) error, options ...SearchOption) (*SearchResponse, error) {
+	if ctx == nil {
+		// No default timeout: onHit may take arbitrarily long for large responses
+		ctx = context.Background()
+	}
+
+	res, indices, err := sr.sendSearch(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	searchResponse, streamed, err := decodeSearchStream(res.Body, onHit)
+	if err != nil {
+		return nil, err
+	}
+
+	sr.client.config.Logger.Debug("Streaming search completed successfully - indices: %s, hits: %d, total: %d, took: %d", strings.Join(indices, ","), streamed, int(searchResponse.Hits.Total.Value), searchResponse.Took)
+
+	return searchResponse, nil
+}
+
+// decodeSearchStream decodes a search response, passing the elements of hits.hits to onHit one at
+// a time and decoding the remaining fields into the returned response. It returns the number of hits streamed.
+func decodeSearchStream(r io.Reader, onHit func(hit Hit) error) (*SearchResponse, int, error) {
+	decoder := json.NewDecoder(r)
+	streamed := 0
+
+	fields := make(map[string]json.RawMessage)
+	hitsFields := make(map[string]json.RawMessage)
+
+	err := decodeObject(decoder, func(key string) error {
+		if key != "hits" {
+			return decodeRawField(decoder, key, fields)
+		}
+
+		return decodeObject(decoder, func(hitsKey string) error {
+			if hitsKey != "hits" {
+				return decodeRawField(decoder, hitsKey, hitsFields)
+			}
+
+			if err := expectDelim(decoder, '['); err != nil {
+				return err
+			}
+			for decoder.More() {
+				var hit Hit
+				if err := decoder.Decode(&hit); err != nil {
+					return fmt.Errorf("failed to decode search hit %d: %w", streamed, err)
+				}
+				streamed++
+				if err := onHit(hit); err != nil {
+					return err
+				}
+			}
+			return expectDelim(decoder, ']')
+		})
+	})
+	if err != nil {
+		return nil, streamed, err
+	}
+
+	hitsBytes, err := json.Marshal(hitsFields)
+	if err != nil {
+		return nil, streamed, fmt.Errorf("failed to decode search response: %w", err)
+	}
+	fields["hits"] = hitsBytes
+
+	responseBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, streamed, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	var searchResponse SearchResponse
+	if err := json.Unmarshal(responseBytes, &searchResponse); err != nil {
+		return nil, streamed, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	return &searchResponse, streamed, nil
+}
+
+// decodeObject reads a JSON object from the decoder, calling field for each key with the
+// decoder positioned at its value; field must consume the value
+func decodeObject(decoder *json.Decoder, field func(key string) error) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to decode search response: %w", err)
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("failed to decode search response: unexpected token %v", token)
+		}
+		if err := field(key); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+// decodeRawField stores the next value of the decoder under key without decoding it
+func decodeRawField(decoder *json.Decoder, key string, fields map[string]json.RawMessage) error {
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("failed to decode search response field '%s': %w", key, err)
+	}
+	fields[key] = raw
+	return nil
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode search response: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to decode search response: expected '%s', got %v", delim, token)
+	}
+	return nil
+}
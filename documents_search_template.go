@@ -0,0 +1,273 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// SearchTemplateRef identifies the template used by a templated search:
+// either an inline Mustache source or the ID of a stored script
+type SearchTemplateRef struct {
+	ID     string
+	Source string
+}
+
+// InlineSearchTemplate references an inline Mustache template,
+// e.g. `{"query": {"match": {"{{field}}": "{{value}}"}}}`
+func InlineSearchTemplate(source string) SearchTemplateRef {
+	return SearchTemplateRef{Source: source}
+}
+
+// StoredSearchTemplate references a template stored with PutScript
+func StoredSearchTemplate(id string) SearchTemplateRef {
+	return SearchTemplateRef{ID: id}
+}
+
+// StoredScript represents a script or search template stored in the cluster state
+type StoredScript struct {
+	ID     string `json:"_id"`
+	Found  bool   `json:"found"`
+	Script struct {
+		Lang   string `json:"lang"`
+		Source string `json:"source"`
+	} `json:"script"`
+}
+
+// ScriptResource provides operations on stored scripts and search templates
+type ScriptResource struct {
+	client *Client
+}
+
+// SearchTemplate runs a search rendered from an inline or stored Mustache template with the given params
+func (s *DocumentsService) SearchTemplate(ctx context.Context, template SearchTemplateRef, params map[string]any, options ...SearchOption) (*SearchResponse, error) {
+	searchResource := &SearchResource{
+		client: s.client,
+	}
+	return searchResource.SearchTemplate(ctx, template, params, options...)
+}
+
+// PutScript stores a script or search template; use lang "mustache" for search templates
+func (s *DocumentsService) PutScript(ctx context.Context, id, lang, source string) error {
+	scriptResource := &ScriptResource{
+		client: s.client,
+	}
+	return scriptResource.Put(ctx, id, lang, source)
+}
+
+// GetScript retrieves a stored script or search template
+func (s *DocumentsService) GetScript(ctx context.Context, id string) (*StoredScript, error) {
+	scriptResource := &ScriptResource{
+		client: s.client,
+	}
+	return scriptResource.Get(ctx, id)
+}
+
+// DeleteScript deletes a stored script or search template
+func (s *DocumentsService) DeleteScript(ctx context.Context, id string) error {
+	scriptResource := &ScriptResource{
+		client: s.client,
+	}
+	return scriptResource.Delete(ctx, id)
+}
+
+// SearchTemplate runs a templated search across the indices selected by the options.
+// Only WithIndices, WithPreference and WithSearchRouting apply; the body comes from the template.
+func (sr *SearchResource) SearchTemplate(ctx context.Context, template SearchTemplateRef, params map[string]any, options ...SearchOption) (*SearchResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	body, err := buildSearchTemplateBody(template, params)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search template: %w", err)
+	}
+
+	indices := extractIndicesFromOptions(options)
+	requestParams := searchRequestParamsFromOptions(options)
+
+	req := esapi.SearchTemplateRequest{
+		Index:                 indices,
+		Body:                  bytes.NewReader(bodyBytes),
+		Preference:            requestParams.preference,
+		Routing:               requestParams.routing,
+		CcsMinimizeRoundtrips: requestParams.ccsMinimizeRoundtrips,
+	}
+
+	res, err := req.Do(ctx, sr.client.client)
+	if err != nil {
+		sr.client.config.Logger.Error("Search template failed - indices: %s, error: %s", strings.Join(indices, ","), err.Error())
+		return nil, fmt.Errorf("search template request failed: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		sr.client.config.Logger.Error("Search template failed - indices: %s, status: %s, response: %s", strings.Join(indices, ","), res.Status(), string(bodyBytes))
+		return nil, fmt.Errorf("search template failed: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var searchResponse SearchResponse
+	if err := sr.client.decodeBody(res.Body, &searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search template response: %w", err)
+	}
+
+	return &searchResponse, nil
+}
+
+// buildSearchTemplateBody builds the _search/template request body
+func buildSearchTemplateBody(template SearchTemplateRef, params map[string]any) (map[string]any, error) {
+	body := map[string]any{}
+
+	switch {
+	case template.ID != "" && template.Source != "":
+		return nil, fmt.Errorf("search template must set either an ID or a source, not both")
+	case template.ID != "":
+		body["id"] = template.ID
+	case template.Source != "":
+		body["source"] = template.Source
+	default:
+		return nil, fmt.Errorf("search template requires an ID or a source")
+	}
+
+	if len(params) > 0 {
+		body["params"] = params
+	}
+
+	return body, nil
+}
+
+// Put stores a script or search template under the given ID
+func (sr *ScriptResource) Put(ctx context.Context, id, lang, source string) error {
+	if err := sr.client.checkWritable("put script"); err != nil {
+		return err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	body := map[string]any{
+		"script": map[string]any{
+			"lang":   lang,
+			"source": source,
+		},
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal script: %w", err)
+	}
+
+	req := esapi.PutScriptRequest{
+		ScriptID: id,
+		Body:     bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, sr.client.client)
+	if err != nil {
+		return fmt.Errorf("failed to put script: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to put script '%s': %s - %s", id, res.Status(), string(body))
+	}
+
+	sr.client.config.Logger.Info("Script stored successfully - id: %s, lang: %s", id, lang)
+	return nil
+}
+
+// Get retrieves a stored script or search template
+func (sr *ScriptResource) Get(ctx context.Context, id string) (*StoredScript, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	req := esapi.GetScriptRequest{
+		ScriptID: id,
+	}
+
+	res, err := req.Do(ctx, sr.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get script: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to get script '%s': %s - %s", id, res.Status(), string(body))
+	}
+
+	var script StoredScript
+	if err := json.NewDecoder(res.Body).Decode(&script); err != nil {
+		return nil, fmt.Errorf("failed to decode script response: %w", err)
+	}
+
+	return &script, nil
+}
+
+// Delete deletes a stored script or search template
+func (sr *ScriptResource) Delete(ctx context.Context, id string) error {
+	if err := sr.client.checkWritable("delete script"); err != nil {
+		return err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	req := esapi.DeleteScriptRequest{
+		ScriptID: id,
+	}
+
+	res, err := req.Do(ctx, sr.client.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete script: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			sr.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to delete script '%s': %s - %s", id, res.Status(), string(body))
+	}
+
+	sr.client.config.Logger.Info("Script deleted successfully - id: %s", id)
+	return nil
+}
@@ -0,0 +1,450 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+)
+
+func TestApplyScrollSize(t *testing.T) {
+	t.Run("uses configured default", func(t *testing.T) {
+		client := &Client{config: &Config{DefaultScrollSize: 250}}
+		body := BuildSearchQuery(MatchAllQuery())
+
+		if err := client.applyScrollSize(body); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if body["size"] != 250 {
+			t.Errorf("Expected size=250, got %v", body["size"])
+		}
+	})
+
+	t.Run("falls back to built-in default", func(t *testing.T) {
+		client := &Client{config: &Config{}}
+		body := BuildSearchQuery(MatchAllQuery())
+
+		if err := client.applyScrollSize(body); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if body["size"] != defaultScrollSize {
+			t.Errorf("Expected size=%d, got %v", defaultScrollSize, body["size"])
+		}
+	})
+
+	t.Run("honors WithSize", func(t *testing.T) {
+		client := &Client{config: &Config{DefaultScrollSize: 250}}
+		body := BuildSearchQuery(MatchAllQuery(), WithSize(50))
+
+		if err := client.applyScrollSize(body); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if body["size"] != 50 {
+			t.Errorf("Expected size=50, got %v", body["size"])
+		}
+	})
+
+	t.Run("rejects sizes above max_result_window", func(t *testing.T) {
+		client := &Client{config: &Config{}}
+		body := BuildSearchQuery(MatchAllQuery(), WithSize(DefaultMaxResultWindow+1))
+
+		if err := client.applyScrollSize(body); err == nil {
+			t.Error("Expected error for scroll size above max_result_window")
+		}
+	})
+}
+
+func TestExtractSearchRequestParams(t *testing.T) {
+	body := BuildSearchQuery(MatchAllQuery(), WithPreference("session-42"), WithSearchRouting("user1", "user2"), WithTerminateAfter(1), WithCCSMinimizeRoundtrips(false), WithSize(10))
+
+	params := extractSearchRequestParams(body)
+
+	if params.preference != "session-42" {
+		t.Errorf("Expected preference 'session-42', got %q", params.preference)
+	}
+	if len(params.routing) != 2 || params.routing[0] != "user1" || params.routing[1] != "user2" {
+		t.Errorf("Expected routing [user1 user2], got %v", params.routing)
+	}
+	if _, ok := body["preference"]; ok {
+		t.Error("Expected preference to be removed from the body")
+	}
+	if _, ok := body["routing"]; ok {
+		t.Error("Expected routing to be removed from the body")
+	}
+	if params.terminateAfter == nil || *params.terminateAfter != 1 {
+		t.Errorf("Expected terminate_after 1, got %v", params.terminateAfter)
+	}
+	if _, ok := body["terminate_after"]; ok {
+		t.Error("Expected terminate_after to be removed from the body")
+	}
+	if params.ccsMinimizeRoundtrips == nil || *params.ccsMinimizeRoundtrips {
+		t.Errorf("Expected ccs_minimize_roundtrips false, got %v", params.ccsMinimizeRoundtrips)
+	}
+	if _, ok := body["ccs_minimize_roundtrips"]; ok {
+		t.Error("Expected ccs_minimize_roundtrips to be removed from the body")
+	}
+	if body["size"] != 10 {
+		t.Errorf("Expected size to stay in the body, got %v", body["size"])
+	}
+}
+
+func TestCheckResultWindow(t *testing.T) {
+	if err := CheckResultWindow(9990, 10, 0); err != nil {
+		t.Errorf("Expected page at the window edge to pass, got %v", err)
+	}
+
+	err := CheckResultWindow(9991, 10, 0)
+	if !errors.Is(err, ErrResultWindowExceeded) {
+		t.Fatalf("Expected ErrResultWindowExceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "WithSearchAfter") {
+		t.Errorf("Expected error to suggest WithSearchAfter, got %q", err.Error())
+	}
+
+	if err := (&Client{config: &Config{}}).checkResultWindow(BuildSearchQuery(MatchAllQuery(), WithFrom(20000))); err != nil {
+		t.Errorf("Expected no client-side check without MaxResultWindow, got %v", err)
+	}
+
+	client := &Client{config: &Config{MaxResultWindow: 500}}
+	if err := client.checkResultWindow(BuildSearchQuery(MatchAllQuery(), WithFrom(495))); err == nil {
+		t.Error("Expected default size to count towards the configured window")
+	}
+	if err := client.checkResultWindow(BuildSearchQuery(MatchAllQuery(), WithFrom(490))); err != nil {
+		t.Errorf("Expected search within the window to pass, got %v", err)
+	}
+}
+
+func TestCheckedPaginatedSearch(t *testing.T) {
+	body, err := CheckedPaginatedSearch(MatchAllQuery(), 3, 20, "created", false, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if body["from"] != 40 || body["size"] != 20 {
+		t.Errorf("Expected from=40 size=20, got from=%v size=%v", body["from"], body["size"])
+	}
+
+	if _, err := CheckedPaginatedSearch(MatchAllQuery(), 1001, 10, "created", false, 0); !errors.Is(err, ErrResultWindowExceeded) {
+		t.Errorf("Expected ErrResultWindowExceeded for page 1001, got %v", err)
+	}
+	if _, err := CheckedPaginatedSearch(MatchAllQuery(), 0, 10, "created", false, 0); err == nil {
+		t.Error("Expected error for page 0")
+	}
+}
+
+func TestBuildSearchTemplateBody(t *testing.T) {
+	params := map[string]any{"field": "title", "value": "go"}
+
+	inline, err := buildSearchTemplateBody(InlineSearchTemplate(`{"query": {"match": {"{{field}}": "{{value}}"}}}`), params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := inline["source"]; !ok {
+		t.Errorf("Expected inline template source, got %v", inline)
+	}
+	if inline["params"].(map[string]any)["value"] != "go" {
+		t.Errorf("Expected params to be passed through, got %v", inline["params"])
+	}
+
+	stored, err := buildSearchTemplateBody(StoredSearchTemplate("product-search"), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stored["id"] != "product-search" {
+		t.Errorf("Expected stored template id, got %v", stored)
+	}
+	if _, ok := stored["params"]; ok {
+		t.Error("Expected no params for an empty params map")
+	}
+
+	if _, err := buildSearchTemplateBody(SearchTemplateRef{}, nil); err == nil {
+		t.Error("Expected error for an empty template reference")
+	}
+	if _, err := buildSearchTemplateBody(SearchTemplateRef{ID: "a", Source: "{}"}, nil); err == nil {
+		t.Error("Expected error when both ID and source are set")
+	}
+}
+
+func TestApplySearchTimeout(t *testing.T) {
+	client := &Client{config: &Config{SearchTimeout: 1500 * time.Millisecond}}
+
+	body := BuildSearchQuery(MatchAllQuery())
+	client.applySearchTimeout(body)
+	if body["timeout"] != "1500ms" {
+		t.Errorf("Expected default timeout '1500ms', got %v", body["timeout"])
+	}
+
+	body = BuildSearchQuery(MatchAllQuery(), WithTimeout("5s"))
+	client.applySearchTimeout(body)
+	if body["timeout"] != "5s" {
+		t.Errorf("Expected WithTimeout to override the default, got %v", body["timeout"])
+	}
+
+	client.config.SearchTimeout = 0
+	body = BuildSearchQuery(MatchAllQuery())
+	client.applySearchTimeout(body)
+	if _, ok := body["timeout"]; ok {
+		t.Error("Expected no timeout when SearchTimeout is unset")
+	}
+}
+
+func TestDecodeSearchStream(t *testing.T) {
+	raw := `{
+		"took": 12,
+		"timed_out": false,
+		"_shards": {"total": 2, "successful": 2, "skipped": 0, "failed": 0},
+		"hits": {
+			"total": {"value": 3, "relation": "eq"},
+			"max_score": 1.5,
+			"hits": [
+				{"_index": "logs", "_id": "a", "_score": 1.5, "_source": {"level": "error"}},
+				{"_index": "logs", "_id": "b", "_score": 1.0, "_source": {"level": "warn"}},
+				{"_index": "logs", "_id": "c", "_score": 0.5, "_source": {"level": "info"}}
+			]
+		},
+		"aggregations": {"levels": {"buckets": []}}
+	}`
+
+	var ids []string
+	response, streamed, err := decodeSearchStream(strings.NewReader(raw), func(hit Hit) error {
+		ids = append(ids, hit.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if streamed != 3 || strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("Expected hits a,b,c to be streamed in order, got %v (%d)", ids, streamed)
+	}
+	if response.Took != 12 || response.Hits.Total.Value != 3 || response.Hits.MaxScore != 1.5 || response.Shards.Successful != 2 {
+		t.Errorf("Expected response metadata to be decoded, got %+v", response)
+	}
+	if len(response.Hits.Hits) != 0 {
+		t.Errorf("Expected streamed hits not to be kept on the response, got %d", len(response.Hits.Hits))
+	}
+	if _, ok := response.Aggregations["levels"]; !ok {
+		t.Errorf("Expected aggregations to be decoded, got %v", response.Aggregations)
+	}
+
+	stop := errors.New("stop")
+	_, streamed, err = decodeSearchStream(strings.NewReader(raw), func(hit Hit) error {
+		if hit.ID == "b" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || streamed != 2 {
+		t.Errorf("Expected decoding to stop at the second hit with the callback error, got %v after %d hits", err, streamed)
+	}
+}
+
+func TestWithFieldsAndDocValueFields(t *testing.T) {
+	body := BuildSearchQuery(MatchAllQuery(),
+		WithFields("user.*"),
+		WithFieldFormat("created_at", "yyyy-MM-dd"),
+		WithDocValueFields("price", "stock"),
+	)
+
+	fields, _ := body["fields"].([]any)
+	if len(fields) != 2 || fields[0] != "user.*" {
+		t.Fatalf("Expected a pattern and a formatted field, got %v", body["fields"])
+	}
+	if formatted := fields[1].(map[string]any); formatted["field"] != "created_at" || formatted["format"] != "yyyy-MM-dd" {
+		t.Errorf("Expected created_at formatted as yyyy-MM-dd, got %v", formatted)
+	}
+	if docValueFields, _ := body["docvalue_fields"].([]any); len(docValueFields) != 2 || docValueFields[1] != "stock" {
+		t.Errorf("Expected docvalue_fields [price stock], got %v", body["docvalue_fields"])
+	}
+
+	var response SearchResponse
+	raw := `{"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+		{"_index": "orders", "_id": "1", "_source": {}, "fields": {"created_at": ["2024-01-15"], "price": [19.99]}}
+	]}}`
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+	hit := result.Hits.Hits[0]
+	if values := hit.FieldValues("created_at"); len(values) != 1 || values[0] != "2024-01-15" {
+		t.Errorf("Expected created_at [2024-01-15], got %v", values)
+	}
+	if values := hit.FieldValues("missing"); values != nil {
+		t.Errorf("Expected no values for a missing field, got %v", values)
+	}
+}
+
+func TestDistinctValuesPagesCompositeAggregation(t *testing.T) {
+	var afterKeys []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode search body: %v", err)
+		}
+		composite := body["aggs"].(map[string]any)["distinct_values"].(map[string]any)["composite"].(map[string]any)
+		afterKeys = append(afterKeys, composite["after"])
+		if body["size"] != float64(0) {
+			t.Errorf("Expected size=0, got %v", body["size"])
+		}
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		switch len(afterKeys) {
+		case 1:
+			_, _ = w.Write([]byte(`{"hits": {"total": {"value": 5, "relation": "eq"}, "hits": []}, "aggregations": {"distinct_values": {
+				"after_key": {"value": "b"},
+				"buckets": [{"key": {"value": "a"}, "doc_count": 3}, {"key": {"value": "b"}, "doc_count": 1}]}}}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"hits": {"total": {"value": 5, "relation": "eq"}, "hits": []}, "aggregations": {"distinct_values": {
+				"after_key": {"value": "c"},
+				"buckets": [{"key": {"value": "c"}, "doc_count": 1}]}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"hits": {"total": {"value": 5, "relation": "eq"}, "hits": []}, "aggregations": {"distinct_values": {"buckets": []}}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	values, err := For[map[string]any](client.Documents()).DistinctValues(context.Background(), "customer.keyword", WithIndices("orders"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(afterKeys) != 3 || afterKeys[0] != nil {
+		t.Fatalf("Expected 3 pages starting without after key, got %v", afterKeys)
+	}
+	if after, _ := afterKeys[1].(map[string]any); after["value"] != "b" {
+		t.Errorf("Expected second page after b, got %v", afterKeys[1])
+	}
+
+	expected := []DistinctValue{{Value: "a", DocCount: 3}, {Value: "b", DocCount: 1}, {Value: "c", DocCount: 1}}
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %d values, got %+v", len(expected), values)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("Expected value %d to be %+v, got %+v", i, expected[i], values[i])
+		}
+	}
+}
+
+func TestSearchRawDecodesHeterogeneousHits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took": 1, "hits": {"total": {"value": 2, "relation": "eq"}, "max_score": 1, "hits": [
+			{"_index": "events", "_id": "o-1", "_score": 1, "_source": {"type": "order", "total": 42.5, "seq": 9007199254740993}},
+			{"_index": "events", "_id": "r-1", "_score": 0.5, "_source": {"type": "refund", "reason": "damaged"}}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	type order struct {
+		Meta
+		Total float64 `json:"total"`
+		Seq   int64   `json:"seq"`
+	}
+	type refund struct {
+		Reason string `json:"reason"`
+	}
+
+	codec := &countingCodec{}
+	client.config.Codec = codec
+
+	result, err := For[any](client.Documents()).SearchRaw(context.Background(), query.MatchAll(), WithIndices("events"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if raw := string(result.Hits.Hits[0].Source); raw != `{"type": "order", "total": 42.5, "seq": 9007199254740993}` {
+		t.Errorf("Expected the source bytes exactly as returned, got %s", raw)
+	}
+	unmarshals := codec.unmarshals.Load()
+
+	var orders []order
+	var refunds []refund
+	for _, hit := range result.Hits.Hits {
+		kind, err := DecodeSource[struct {
+			Type string `json:"type"`
+		}](client.Codec(), hit)
+		if err != nil {
+			t.Fatalf("Failed to decode discriminator: %v", err)
+		}
+		switch kind.Type {
+		case "order":
+			doc, err := DecodeSource[order](client.Codec(), hit)
+			if err != nil {
+				t.Fatalf("Failed to decode order: %v", err)
+			}
+			orders = append(orders, doc)
+		case "refund":
+			doc, err := DecodeSource[refund](client.Codec(), hit)
+			if err != nil {
+				t.Fatalf("Failed to decode refund: %v", err)
+			}
+			refunds = append(refunds, doc)
+		}
+	}
+
+	if len(orders) != 1 || orders[0].Total != 42.5 || orders[0].ID != "o-1" || orders[0].Score != 1 {
+		t.Errorf("Expected one order with its metadata, got %+v", orders)
+	}
+	if len(orders) == 1 && orders[0].Seq != 9007199254740993 {
+		t.Errorf("Expected seq 9007199254740993 without precision loss, got %d", orders[0].Seq)
+	}
+	if got := codec.unmarshals.Load() - unmarshals; got != 4 {
+		t.Errorf("Expected DecodeSource to use the client codec 4 times, got %d", got)
+	}
+	if len(refunds) != 1 || refunds[0].Reason != "damaged" {
+		t.Errorf("Expected one refund, got %+v", refunds)
+	}
+}
+
+func TestScrollWithLimitRefusesLargeExports(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/_count") {
+			_, _ = w.Write([]byte(`{"count": 2500000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"_scroll_id": "scroll-1", "hits": {"total": {"value": 2500000, "relation": "eq"}, "hits": []}}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	typedDocs := For[map[string]any](client.Documents())
+
+	_, err := typedDocs.ScrollWithLimit(context.Background(), query.MatchAll(), 100000, time.Minute, WithIndices("logs"))
+	var limitErr *ScrollLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, ErrScrollLimitExceeded) {
+		t.Fatalf("Expected a scroll limit error, got %v", err)
+	}
+	if limitErr.Count != 2500000 || limitErr.MaxDocs != 100000 {
+		t.Errorf("Unexpected limit error details: %+v", limitErr)
+	}
+	if len(paths) != 1 || paths[0] != "/logs/_count" {
+		t.Errorf("Expected only a count request, got %v", paths)
+	}
+
+	iterator, err := typedDocs.ScrollWithLimit(context.Background(), query.MatchAll(), 5000000, time.Minute, WithIndices("logs"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if iterator.TotalHits() != 2500000 || len(paths) != 3 || paths[2] != "/logs/_search" {
+		t.Errorf("Expected the scroll to start after the count, got total %d and requests %v", iterator.TotalHits(), paths)
+	}
+}
@@ -0,0 +1,76 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UpdateOption configures a partial document update
+type UpdateOption func(*updateOptions)
+
+// updateOptions holds the resolved update options
+type updateOptions struct {
+	source          bool
+	retryOnConflict int
+}
+
+// WithUpdateSource requests the updated document in the response (UpdateResponse.Get),
+// saving a follow-up Get to read back the merged document
+func WithUpdateSource(enabled bool) UpdateOption {
+	return func(opts *updateOptions) {
+		opts.source = enabled
+	}
+}
+
+// WithRetryOnConflict retries the update up to n times when the document changed between its get
+// and index phases. Scripted updates of contended documents, such as counter increments, fail with
+// version conflicts without it. n must not be negative.
+func WithRetryOnConflict(n int) UpdateOption {
+	return func(opts *updateOptions) {
+		opts.retryOnConflict = n
+	}
+}
+
+// validate checks that the options hold supported values
+func (o updateOptions) validate() error {
+	if o.retryOnConflict < 0 {
+		return fmt.Errorf("retry_on_conflict cannot be negative, got %d", o.retryOnConflict)
+	}
+	return nil
+}
+
+// buildUpdateOptions applies the given options
+func buildUpdateOptions(options []UpdateOption) updateOptions {
+	var opts updateOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// Update partially updates a document and returns the merged document decoded as T
+func (t *TypedDocuments[T]) Update(ctx context.Context, indexName, documentID string, partial any, options ...UpdateOption) (T, *UpdateResponse, error) {
+	var doc T
+
+	options = append(options, WithUpdateSource(true))
+	response, err := t.service.Update(ctx, indexName, documentID, partial, options...)
+	if err != nil {
+		return doc, nil, err
+	}
+
+	doc, err = decodeUpdatedSource[T](response)
+	return doc, response, err
+}
+
+// decodeUpdatedSource decodes the document returned by an update request
+func decodeUpdatedSource[T any](response *UpdateResponse) (T, error) {
+	var doc T
+	if response.Get == nil || len(response.Get.Source) == 0 {
+		return doc, fmt.Errorf("update response for document '%s' does not include _source", response.ID)
+	}
+	if err := json.Unmarshal(response.Get.Source, &doc); err != nil {
+		return doc, fmt.Errorf("failed to unmarshal updated source to type %T: %w", doc, err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,34 @@
+package elastic
+
+// WriteOption configures a single-document or bulk index/create write
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the resolved write options
+type writeOptions struct {
+	requireAlias bool
+}
+
+// WithRequireAlias makes the write fail unless the target name is an alias, guarding rollover and
+// data stream setups against writing to a concrete backing index instead of the write alias
+func WithRequireAlias(enabled bool) WriteOption {
+	return func(opts *writeOptions) {
+		opts.requireAlias = enabled
+	}
+}
+
+// buildWriteOptions applies the given options
+func buildWriteOptions(options []WriteOption) writeOptions {
+	var opts writeOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// requireAliasParam returns the require_alias parameter, or nil to leave it unset
+func (o writeOptions) requireAliasParam() *bool {
+	if !o.requireAlias {
+		return nil
+	}
+	return &o.requireAlias
+}
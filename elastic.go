@@ -28,11 +28,20 @@
 //   - ELASTICSEARCH_CLOUD_ID: Elastic Cloud ID
 //   - ELASTICSEARCH_INDEX_PREFIX: Prefix for all index names
 //   - ELASTICSEARCH_ID_MODE: ID generation mode (elastic=default, ulid=time-ordered, custom=user-provided)
+//   - ELASTICSEARCH_SKIP_UPDATE_TIMESTAMP: Don't inject updated_at into partial updates (default: false)
+//   - ELASTICSEARCH_READ_ONLY: Reject document and index writes with ErrReadOnly (default: false)
+//   - ELASTICSEARCH_DEFAULT_SCROLL_SIZE: Batch size for scroll searches (default: 1000)
+//   - ELASTICSEARCH_MAX_RESULT_WINDOW: Reject searches whose from + size exceeds this client-side (default: 0, disabled)
+//   - ELASTICSEARCH_SEARCH_TIMEOUT: Server-side timeout for searches without WithTimeout (default: 0s, none)
 //   - ELASTICSEARCH_TLS_ENABLED: Enable TLS (default: false)
 //   - ELASTICSEARCH_TLS_INSECURE: Allow insecure TLS (default: false)
 //   - ELASTICSEARCH_COMPRESSION_ENABLED: Enable compression (default: true)
+//   - ELASTICSEARCH_DISABLE_RETRY: Disable automatic retries (default: false)
 //   - ELASTICSEARCH_RETRY_ON_STATUS: Retry on these HTTP status codes
 //   - ELASTICSEARCH_MAX_RETRIES: Maximum number of retries (default: 3)
+//   - ELASTICSEARCH_DISCOVER_NODES_INTERVAL: Periodic node discovery interval (default: 0s, disabled)
+//   - ELASTICSEARCH_REQUEST_COMPRESSION_THRESHOLD: Gzip request bodies of at least this many bytes (default: 0, disabled)
+//   - ELASTICSEARCH_LAZY_CONNECT: Skip the connectivity check when creating the client (default: false)
 //   - ELASTICSEARCH_CONNECTION_NAME: Connection identifier for logging
 //   - ELASTICSEARCH_APP_NAME: Application name for connection metadata
 //   - ELASTICSEARCH_LOG_LEVEL: Logging level (default: info)
@@ -0,0 +1,245 @@
+// Package elastictest provides an in-memory HTTP transport for unit testing code that uses the
+// go-elastic client without a running Elasticsearch cluster.
+//
+//	transport := elastictest.NewTransport().
+//		On(http.MethodGet, "/products/_doc/1", http.StatusOK, `{"_id": "1", "found": true, "_source": {"name": "widget"}}`)
+//	client := elastictest.NewClient(t, transport)
+//
+//	doc, err := client.Documents().Get(ctx, "products", "1")
+//
+// A transport created with NewRecorder forwards requests to a real cluster and records the
+// responses, which Save writes as a fixture that Load replays later.
+package elastictest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	elastic "github.com/cloudresty/go-elastic"
+)
+
+// infoResponse answers GET / unless a test registers its own response, so clients can connect
+const infoResponse = `{"name": "elastictest", "cluster_name": "elastictest", "version": {"number": "9.1.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`
+
+// Interaction is a canned response for requests with the given method and path
+type Interaction struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+// Request is a request received by the transport
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Transport is an http.RoundTripper that answers requests with canned responses keyed by method
+// and path, and records every request it receives. It is safe for concurrent use.
+type Transport struct {
+	next http.RoundTripper
+
+	mutex        sync.Mutex
+	interactions map[string][]Interaction
+	recorded     []Interaction
+	requests     []Request
+}
+
+// NewTransport creates a transport that only serves canned responses. Requests without one are
+// answered with 404 and an error body naming the request.
+func NewTransport() *Transport {
+	return &Transport{
+		interactions: make(map[string][]Interaction),
+	}
+}
+
+// NewRecorder creates a transport that forwards requests without a canned response to next and
+// records the responses, so they can be saved with Save and replayed with Load
+func NewRecorder(next http.RoundTripper) *Transport {
+	transport := NewTransport()
+	transport.next = next
+	return transport
+}
+
+// On queues a response for requests with the given method and path (without query string).
+// Responses queued for the same request are served in order; the last one is repeated.
+func (t *Transport) On(method, path string, status int, body string) *Transport {
+	t.add(Interaction{Method: method, Path: path, Status: status, Body: body})
+	return t
+}
+
+// add queues an interaction
+func (t *Transport) add(interaction Interaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := interactionKey(interaction.Method, interaction.Path)
+	t.interactions[key] = append(t.interactions[key], interaction)
+}
+
+// Requests returns the requests received so far, in order
+func (t *Transport) Requests() []Request {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]Request(nil), t.requests...)
+}
+
+// Save writes the interactions recorded from the next transport as a JSON fixture
+func (t *Transport) Save(w io.Writer) error {
+	t.mutex.Lock()
+	recorded := append([]Interaction(nil), t.recorded...)
+	t.mutex.Unlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(recorded); err != nil {
+		return fmt.Errorf("failed to encode interactions: %w", err)
+	}
+	return nil
+}
+
+// Load queues the interactions of a JSON fixture written by Save
+func (t *Transport) Load(r io.Reader) error {
+	var interactions []Interaction
+	if err := json.NewDecoder(r).Decode(&interactions); err != nil {
+		return fmt.Errorf("failed to decode interactions: %w", err)
+	}
+	for _, interaction := range interactions {
+		t.add(interaction)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		_ = req.Body.Close()
+	}
+
+	t.mutex.Lock()
+	t.requests = append(t.requests, Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Body:   body,
+	})
+	interaction, found := t.take(req.Method, req.URL.Path)
+	t.mutex.Unlock()
+
+	if !found && t.next != nil {
+		return t.record(req, body)
+	}
+	if !found {
+		interaction = Interaction{
+			Status: http.StatusNotFound,
+			Body:   fmt.Sprintf(`{"error": {"type": "elastictest_no_response", "reason": "no canned response for %s %s"}, "status": 404}`, req.Method, req.URL.Path),
+		}
+	}
+
+	return newResponse(req, interaction.Status, interaction.Body), nil
+}
+
+// take pops the next canned response for a request, keeping the last one. The caller holds the mutex.
+func (t *Transport) take(method, path string) (Interaction, bool) {
+	key := interactionKey(method, path)
+	queue := t.interactions[key]
+	if len(queue) == 0 {
+		if path == "/" && (method == http.MethodGet || method == http.MethodHead) {
+			return Interaction{Method: method, Path: path, Status: http.StatusOK, Body: infoResponse}, true
+		}
+		return Interaction{}, false
+	}
+	if len(queue) > 1 {
+		t.interactions[key] = queue[1:]
+	}
+	return queue[0], true
+}
+
+// record forwards a request to the next transport and records its response
+func (t *Transport) record(req *http.Request, body []byte) (*http.Response, error) {
+	forwarded := req.Clone(req.Context())
+	forwarded.Body = io.NopCloser(bytes.NewReader(body))
+
+	res, err := t.next.RoundTrip(forwarded)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded response body: %w", err)
+	}
+
+	t.mutex.Lock()
+	t.recorded = append(t.recorded, Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Status: res.StatusCode,
+		Body:   string(responseBody),
+	})
+	t.mutex.Unlock()
+
+	return newResponse(req, res.StatusCode, string(responseBody)), nil
+}
+
+// newResponse builds a JSON response that passes the client's Elasticsearch product check
+func newResponse(req *http.Request, status int, body string) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Elastic-Product", "Elasticsearch")
+
+	if req.Method == http.MethodHead {
+		body = ""
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// interactionKey identifies the canned responses of a request
+func interactionKey(method, path string) string {
+	return method + " " + path
+}
+
+// NewClient creates a client that sends every request to transport and is closed when the test ends
+func NewClient(tb testing.TB, transport http.RoundTripper, options ...elastic.ClientOption) *elastic.Client {
+	tb.Helper()
+
+	clientOptions := append([]elastic.ClientOption{
+		elastic.WithHosts("localhost:9200"),
+		elastic.WithTransport(transport),
+	}, options...)
+
+	client, err := elastic.NewClient(clientOptions...)
+	if err != nil {
+		tb.Fatalf("Failed to create client: %v", err)
+	}
+	tb.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
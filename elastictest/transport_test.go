@@ -0,0 +1,84 @@
+package elastictest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	elastic "github.com/cloudresty/go-elastic"
+	"github.com/cloudresty/go-elastic/elastictest"
+)
+
+func TestTransportServesCannedResponses(t *testing.T) {
+	transport := elastictest.NewTransport().
+		On(http.MethodGet, "/products/_doc/1", http.StatusOK, `{"_index": "products", "_id": "1", "found": true, "_source": {"name": "widget"}}`).
+		On(http.MethodPost, "/products/_count", http.StatusOK, `{"count": 1}`).
+		On(http.MethodPost, "/products/_count", http.StatusOK, `{"count": 2}`)
+	client := elastictest.NewClient(t, transport)
+
+	doc, err := client.Documents().Get(context.Background(), "products", "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc["name"] != "widget" {
+		t.Errorf("Expected the canned document, got %v", doc)
+	}
+
+	for _, expected := range []int64{1, 2, 2} {
+		count, err := client.Documents().CountAll(context.Background(), "products")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count != expected {
+			t.Errorf("Expected queued count %d, got %d", expected, count)
+		}
+	}
+
+	if _, err := client.Documents().Get(context.Background(), "products", "2"); err == nil {
+		t.Error("Expected a request without canned response to fail")
+	}
+
+	requests := transport.Requests()
+	last := requests[len(requests)-1]
+	if last.Method != http.MethodGet || last.Path != "/products/_doc/2" {
+		t.Errorf("Expected the last request to be recorded, got %s %s", last.Method, last.Path)
+	}
+}
+
+func TestRecorderReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count": 42}`))
+	}))
+	defer server.Close()
+
+	recorder := elastictest.NewRecorder(http.DefaultTransport)
+	recording := elastictest.NewClient(t, recorder, elastic.WithHosts(strings.TrimPrefix(server.URL, "http://")))
+	if _, err := recording.Documents().CountAll(context.Background(), "logs"); err != nil {
+		t.Fatalf("Unexpected error while recording: %v", err)
+	}
+
+	var fixture bytes.Buffer
+	if err := recorder.Save(&fixture); err != nil {
+		t.Fatalf("Failed to save fixture: %v", err)
+	}
+	server.Close()
+
+	replay := elastictest.NewTransport()
+	if err := replay.Load(&fixture); err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	client := elastictest.NewClient(t, replay)
+
+	count, err := client.Documents().CountAll(context.Background(), "logs")
+	if err != nil {
+		t.Fatalf("Unexpected error while replaying: %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected the recorded count 42, got %d", count)
+	}
+}
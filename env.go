@@ -106,6 +106,11 @@ func validateConfig(config *Config) error {
 		return errors.New("max retries cannot be negative")
 	}
 
+	// Validate discovery settings
+	if config.DiscoverNodesInterval < 0 {
+		return errors.New("discover nodes interval cannot be negative")
+	}
+
 	// Validate reconnection settings
 	if config.ReconnectDelay <= 0 {
 		config.ReconnectDelay = 5 * time.Second
@@ -120,6 +125,17 @@ func validateConfig(config *Config) error {
 		config.MaxReconnectAttempts = 10
 	}
 
+	// Validate search settings
+	if config.DefaultScrollSize <= 0 {
+		config.DefaultScrollSize = defaultScrollSize
+	}
+	if config.MaxResultWindow < 0 {
+		return errors.New("max result window cannot be negative")
+	}
+	if maxWindow := (&Client{config: config}).maxResultWindow(); config.DefaultScrollSize > maxWindow {
+		return fmt.Errorf("default scroll size cannot exceed %d", maxWindow)
+	}
+
 	// Validate health check settings
 	if config.HealthCheckInterval <= 0 {
 		config.HealthCheckInterval = 30 * time.Second
@@ -148,33 +164,42 @@ func isValidIDMode(mode string) bool {
 
 // Environment variable names for reference
 const (
-	EnvElasticsearchHost                 = "ELASTICSEARCH_HOST"
-	EnvElasticsearchPort                 = "ELASTICSEARCH_PORT"
-	EnvElasticsearchUsername             = "ELASTICSEARCH_USERNAME"
-	EnvElasticsearchPassword             = "ELASTICSEARCH_PASSWORD"
-	EnvElasticsearchAPIKey               = "ELASTICSEARCH_API_KEY"
-	EnvElasticsearchCloudID              = "ELASTICSEARCH_CLOUD_ID"
-	EnvElasticsearchServiceToken         = "ELASTICSEARCH_SERVICE_TOKEN"
-	EnvElasticsearchTLSEnabled           = "ELASTICSEARCH_TLS_ENABLED"
-	EnvElasticsearchTLSInsecure          = "ELASTICSEARCH_TLS_INSECURE"
-	EnvElasticsearchCompressionEnabled   = "ELASTICSEARCH_COMPRESSION_ENABLED"
-	EnvElasticsearchRetryOnStatus        = "ELASTICSEARCH_RETRY_ON_STATUS"
-	EnvElasticsearchMaxRetries           = "ELASTICSEARCH_MAX_RETRIES"
-	EnvElasticsearchDiscoverNodesOnStart = "ELASTICSEARCH_DISCOVER_NODES_ON_START"
-	EnvElasticsearchMaxIdleConns         = "ELASTICSEARCH_MAX_IDLE_CONNS"
-	EnvElasticsearchMaxIdleConnsPerHost  = "ELASTICSEARCH_MAX_IDLE_CONNS_PER_HOST"
-	EnvElasticsearchIdleConnTimeout      = "ELASTICSEARCH_IDLE_CONN_TIMEOUT"
-	EnvElasticsearchMaxConnLifetime      = "ELASTICSEARCH_MAX_CONN_LIFETIME"
-	EnvElasticsearchConnectTimeout       = "ELASTICSEARCH_CONNECT_TIMEOUT"
-	EnvElasticsearchRequestTimeout       = "ELASTICSEARCH_REQUEST_TIMEOUT"
-	EnvElasticsearchReconnectEnabled     = "ELASTICSEARCH_RECONNECT_ENABLED"
-	EnvElasticsearchReconnectDelay       = "ELASTICSEARCH_RECONNECT_DELAY"
-	EnvElasticsearchMaxReconnectDelay    = "ELASTICSEARCH_MAX_RECONNECT_DELAY"
-	EnvElasticsearchReconnectBackoff     = "ELASTICSEARCH_RECONNECT_BACKOFF"
-	EnvElasticsearchMaxReconnectAttempts = "ELASTICSEARCH_MAX_RECONNECT_ATTEMPTS"
-	EnvElasticsearchHealthCheckEnabled   = "ELASTICSEARCH_HEALTH_CHECK_ENABLED"
-	EnvElasticsearchHealthCheckInterval  = "ELASTICSEARCH_HEALTH_CHECK_INTERVAL"
-	EnvElasticsearchAppName              = "ELASTICSEARCH_APP_NAME"
-	EnvElasticsearchConnectionName       = "ELASTICSEARCH_CONNECTION_NAME"
-	EnvElasticsearchIDMode               = "ELASTICSEARCH_ID_MODE"
+	EnvElasticsearchHost                        = "ELASTICSEARCH_HOST"
+	EnvElasticsearchPort                        = "ELASTICSEARCH_PORT"
+	EnvElasticsearchUsername                    = "ELASTICSEARCH_USERNAME"
+	EnvElasticsearchPassword                    = "ELASTICSEARCH_PASSWORD"
+	EnvElasticsearchAPIKey                      = "ELASTICSEARCH_API_KEY"
+	EnvElasticsearchCloudID                     = "ELASTICSEARCH_CLOUD_ID"
+	EnvElasticsearchServiceToken                = "ELASTICSEARCH_SERVICE_TOKEN"
+	EnvElasticsearchTLSEnabled                  = "ELASTICSEARCH_TLS_ENABLED"
+	EnvElasticsearchTLSInsecure                 = "ELASTICSEARCH_TLS_INSECURE"
+	EnvElasticsearchCompressionEnabled          = "ELASTICSEARCH_COMPRESSION_ENABLED"
+	EnvElasticsearchDisableRetry                = "ELASTICSEARCH_DISABLE_RETRY"
+	EnvElasticsearchRetryOnStatus               = "ELASTICSEARCH_RETRY_ON_STATUS"
+	EnvElasticsearchMaxRetries                  = "ELASTICSEARCH_MAX_RETRIES"
+	EnvElasticsearchDiscoverNodesOnStart        = "ELASTICSEARCH_DISCOVER_NODES_ON_START"
+	EnvElasticsearchDiscoverNodesInterval       = "ELASTICSEARCH_DISCOVER_NODES_INTERVAL"
+	EnvElasticsearchRequestCompressionThreshold = "ELASTICSEARCH_REQUEST_COMPRESSION_THRESHOLD"
+	EnvElasticsearchMaxIdleConns                = "ELASTICSEARCH_MAX_IDLE_CONNS"
+	EnvElasticsearchMaxIdleConnsPerHost         = "ELASTICSEARCH_MAX_IDLE_CONNS_PER_HOST"
+	EnvElasticsearchIdleConnTimeout             = "ELASTICSEARCH_IDLE_CONN_TIMEOUT"
+	EnvElasticsearchMaxConnLifetime             = "ELASTICSEARCH_MAX_CONN_LIFETIME"
+	EnvElasticsearchLazyConnect                 = "ELASTICSEARCH_LAZY_CONNECT"
+	EnvElasticsearchConnectTimeout              = "ELASTICSEARCH_CONNECT_TIMEOUT"
+	EnvElasticsearchRequestTimeout              = "ELASTICSEARCH_REQUEST_TIMEOUT"
+	EnvElasticsearchReconnectEnabled            = "ELASTICSEARCH_RECONNECT_ENABLED"
+	EnvElasticsearchReconnectDelay              = "ELASTICSEARCH_RECONNECT_DELAY"
+	EnvElasticsearchMaxReconnectDelay           = "ELASTICSEARCH_MAX_RECONNECT_DELAY"
+	EnvElasticsearchReconnectBackoff            = "ELASTICSEARCH_RECONNECT_BACKOFF"
+	EnvElasticsearchMaxReconnectAttempts        = "ELASTICSEARCH_MAX_RECONNECT_ATTEMPTS"
+	EnvElasticsearchHealthCheckEnabled          = "ELASTICSEARCH_HEALTH_CHECK_ENABLED"
+	EnvElasticsearchHealthCheckInterval         = "ELASTICSEARCH_HEALTH_CHECK_INTERVAL"
+	EnvElasticsearchAppName                     = "ELASTICSEARCH_APP_NAME"
+	EnvElasticsearchConnectionName              = "ELASTICSEARCH_CONNECTION_NAME"
+	EnvElasticsearchIDMode                      = "ELASTICSEARCH_ID_MODE"
+	EnvElasticsearchSkipUpdateTimestamp         = "ELASTICSEARCH_SKIP_UPDATE_TIMESTAMP"
+	EnvElasticsearchReadOnly                    = "ELASTICSEARCH_READ_ONLY"
+	EnvElasticsearchDefaultScrollSize           = "ELASTICSEARCH_DEFAULT_SCROLL_SIZE"
+	EnvElasticsearchMaxResultWindow             = "ELASTICSEARCH_MAX_RESULT_WINDOW"
+	EnvElasticsearchSearchTimeout               = "ELASTICSEARCH_SEARCH_TIMEOUT"
 )
@@ -1,6 +1,134 @@
 package elastic
 
-import "strings"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedByServer is returned when an API is not available on the connected Elasticsearch version
+var ErrUnsupportedByServer = errors.New("unsupported by server")
+
+// UnsupportedFeatureError describes a feature that requires a newer Elasticsearch version
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	MinVersion    string
+	ServerVersion string
+}
+
+// Error implements the error interface
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s: %s requires Elasticsearch %s or later, connected server is %s",
+		ErrUnsupportedByServer.Error(), e.Feature, e.MinVersion, e.ServerVersion)
+}
+
+// Is allows errors.Is(err, ErrUnsupportedByServer) to match
+func (e *UnsupportedFeatureError) Is(target error) bool {
+	return target == ErrUnsupportedByServer
+}
+
+// ErrInvalidDocument is returned when Config.DocumentValidator rejects a document
+var ErrInvalidDocument = errors.New("document validation failed")
+
+// ErrReadOnly is returned when a write operation is attempted on a client configured with WithReadOnly
+var ErrReadOnly = errors.New("client is read-only")
+
+// ErrIndexAlreadyExists is returned by index creation when the index already exists
+var ErrIndexAlreadyExists = errors.New("index already exists")
+
+// ErrNotReady is returned by Client.Ready when the client cannot currently serve requests
+var ErrNotReady = errors.New("client not ready")
+
+// ErrResultWindowExceeded is returned when from + size goes beyond index.max_result_window
+var ErrResultWindowExceeded = errors.New("result window exceeded")
+
+// ResultWindowError describes a search page that lies beyond the max result window
+type ResultWindowError struct {
+	From            int
+	Size            int
+	MaxResultWindow int
+}
+
+// Error implements the error interface
+func (e *ResultWindowError) Error() string {
+	return fmt.Sprintf("%s: from (%d) + size (%d) = %d is greater than index.max_result_window (%d); use WithSearchAfter or Scroll for deep pagination",
+		ErrResultWindowExceeded.Error(), e.From, e.Size, e.From+e.Size, e.MaxResultWindow)
+}
+
+// Is allows errors.Is(err, ErrResultWindowExceeded) to match
+func (e *ResultWindowError) Is(target error) bool {
+	return target == ErrResultWindowExceeded
+}
+
+// ErrScrollLimitExceeded is returned by ScrollWithLimit when a query matches more documents than allowed
+var ErrScrollLimitExceeded = errors.New("scroll limit exceeded")
+
+// ScrollLimitError describes a scroll that was refused because the query matches too many documents
+type ScrollLimitError struct {
+	Count   int64
+	MaxDocs int64
+}
+
+// Error implements the error interface
+func (e *ScrollLimitError) Error() string {
+	return fmt.Sprintf("%s: query matches %d documents, more than the limit of %d; narrow the query or raise the limit",
+		ErrScrollLimitExceeded.Error(), e.Count, e.MaxDocs)
+}
+
+// Is allows errors.Is(err, ErrScrollLimitExceeded) to match
+func (e *ScrollLimitError) Is(target error) bool {
+	return target == ErrScrollLimitExceeded
+}
+
+// ErrBulkRequestFailed is returned when a bulk request as a whole fails, rather than some of its items
+var ErrBulkRequestFailed = errors.New("bulk request failed")
+
+// BulkRequestError describes a bulk request that could not be sent (Err is set) or that
+// Elasticsearch rejected as a whole with an error status
+type BulkRequestError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	Err        error
+}
+
+// Error implements the error interface
+func (e *BulkRequestError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s", ErrBulkRequestFailed.Error(), e.Err.Error())
+	}
+	return fmt.Sprintf("bulk operation failed: %s - %s", e.Status, e.Body)
+}
+
+// Unwrap returns the transport error, if any
+func (e *BulkRequestError) Unwrap() error {
+	return e.Err
+}
+
+// Is allows errors.Is(err, ErrBulkRequestFailed) to match
+func (e *BulkRequestError) Is(target error) bool {
+	return target == ErrBulkRequestFailed
+}
+
+// Retryable reports whether resending the same request is safe and may succeed: only a 429,
+// where Elasticsearch rejected the whole request before applying any of it. Connection errors
+// and 5xx statuses are not, since the request may have been applied before the failure.
+func (e *BulkRequestError) Retryable() bool {
+	return e.Err == nil && e.StatusCode == 429
+}
+
+// errorType returns the type of the error in an Elasticsearch error response body,
+// or an empty string if the body doesn't hold one
+func errorType(body []byte) string {
+	var response struct {
+		Error ErrorCause `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ""
+	}
+	return response.Error.Type
+}
 
 // Error handling utilities
 
@@ -41,7 +41,7 @@ func main() {
 
 	// Create an index
 	indexName := "test-index"
-	err = client.Indices().Create(ctx, indexName, nil)
+	_, err = client.Indices().Create(ctx, indexName, nil)
 	if err != nil {
 		emit.Warn.StructuredFields("Failed to create index (may already exist)",
 			emit.ZString("index", indexName),
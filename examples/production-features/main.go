@@ -55,6 +55,7 @@ func main() {
 		elastic.FromEnvWithPrefix("PAYMENTS_"),
 		elastic.WithConnectionName("payments-cluster"),
 		elastic.WithLogger(logger),
+		elastic.WithHealthCheckCallback(healthCheckReporter("payments")),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create payments client: %v", err)
@@ -64,6 +65,7 @@ func main() {
 		elastic.FromEnvWithPrefix("ORDERS_"),
 		elastic.WithConnectionName("orders-cluster"),
 		elastic.WithLogger(logger),
+		elastic.WithHealthCheckCallback(healthCheckReporter("orders")),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create orders client: %v", err)
@@ -80,14 +82,13 @@ func main() {
 	shutdownManager.Register(paymentsClient, ordersClient)
 	shutdownManager.SetupSignalHandler()
 
-	// Start background workers in separate goroutines
-	go func() {
-		backgroundHealthChecker(shutdownManager.Context(), paymentsClient, "payments")
-	}()
-
-	go func() {
-		backgroundHealthChecker(shutdownManager.Context(), ordersClient, "orders")
-	}()
+	// Flush in-flight writes to disk before the clients are closed
+	shutdownManager.OnShutdown(func(ctx context.Context) error {
+		if err := paymentsClient.Indices().Flush(ctx); err != nil {
+			return err
+		}
+		return ordersClient.Indices().Flush(ctx)
+	})
 
 	// Demonstrate multi-client operations
 	demonstrateMultiClientOperations(paymentsClient, ordersClient)
@@ -203,34 +204,17 @@ func demonstrateMultiClientOperations(paymentsClient, ordersClient *elastic.Clie
 		emit.ZString("orders_cluster", ordersClusterName))
 }
 
-func backgroundHealthChecker(ctx context.Context, client *elastic.Client, serviceName string) {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
-
-	emit.Info.StructuredFields("Starting background health checker",
-		emit.ZString("service", serviceName))
-
-	for {
-		select {
-		case <-ctx.Done():
-			emit.Info.StructuredFields("Health checker shutting down",
-				emit.ZString("service", serviceName))
+// healthCheckReporter returns a callback that logs the outcome of each background health check
+// the client runs on its own (see HealthCheckInterval)
+func healthCheckReporter(serviceName string) func(healthy bool, err error) {
+	return func(healthy bool, err error) {
+		if !healthy {
+			emit.Warn.StructuredFields("Service health check failed",
+				emit.ZString("service", serviceName),
+				emit.ZString("error", err.Error()))
 			return
-		case <-ticker.C:
-			isHealthy := client.Ping(ctx) == nil
-			stats := client.Stats()
-			clientName := client.Name()
-			if !isHealthy {
-				emit.Warn.StructuredFields("Service health check failed",
-					emit.ZString("service", serviceName),
-					emit.ZString("client_name", clientName),
-					emit.ZInt64("reconnects", stats.Reconnects))
-			} else {
-				emit.Debug.StructuredFields("Service health check passed",
-					emit.ZString("service", serviceName),
-					emit.ZString("client_name", clientName),
-					emit.ZBool("connected", stats.IsConnected))
-			}
 		}
+		emit.Debug.StructuredFields("Service health check passed",
+			emit.ZString("service", serviceName))
 	}
 }
@@ -25,7 +25,7 @@ func main() {
 	// --- Index Operations ---
 	// Access the Indices service and call its Create method
 	log.Println("Creating index...")
-	err = client.Indices().Create(ctx, "my-new-index", nil)
+	_, err = client.Indices().Create(ctx, "my-new-index", nil)
 	if err != nil {
 		log.Printf("Error creating index (may already exist): %s", err)
 	}
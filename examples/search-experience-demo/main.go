@@ -260,18 +260,17 @@ func demoCompleteExperience(client *elastic.Client, ctx context.Context) {
 		})
 
 		// Statistical analysis
-		products := result.Documents()
-		var totalPrice float64
-		var totalRating float64
-
-		for _, product := range products {
-			totalPrice += product.Price
-			totalRating += product.Rating
-		}
+		count := float64(len(result.Hits.Hits))
+		totalPrice := elastic.Reduce(result, 0.0, func(sum float64, p Product) float64 {
+			return sum + p.Price
+		})
+		totalRating := elastic.Reduce(result, 0.0, func(sum float64, p Product) float64 {
+			return sum + p.Rating
+		})
 
 		fmt.Printf("\n📈 Statistics:\n")
-		fmt.Printf("Average price: $%.2f\n", totalPrice/float64(len(products)))
-		fmt.Printf("Average rating: %.2f\n", totalRating/float64(len(products)))
+		fmt.Printf("Average price: $%.2f\n", totalPrice/count)
+		fmt.Printf("Average rating: %.2f\n", totalRating/count)
 
 		// Premium products
 		premium := result.Filter(func(p Product) bool {
@@ -1,6 +1,7 @@
 package elastic
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -99,6 +100,59 @@ func TestUserProvidedID(t *testing.T) {
 	}
 }
 
+func TestPrepareDocumentStripsID(t *testing.T) {
+	client := &Client{config: &Config{IDMode: IDModeULID}}
+
+	t.Run("generated ID", func(t *testing.T) {
+		id, body, err := client.prepareDocument("", map[string]any{"name": "test"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(id) != 26 {
+			t.Errorf("Expected generated ULID of length 26, got %q", id)
+		}
+		assertNoSourceID(t, body.Bytes())
+	})
+
+	t.Run("explicit ID", func(t *testing.T) {
+		id, body, err := client.prepareDocument("explicit-id", map[string]any{"name": "test"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if id != "explicit-id" {
+			t.Errorf("Expected explicit ID to be kept, got %q", id)
+		}
+		assertNoSourceID(t, body.Bytes())
+	})
+
+	t.Run("user-provided _id field", func(t *testing.T) {
+		id, body, err := client.prepareDocument("", map[string]any{"_id": "from-doc", "name": "test"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if id != "from-doc" {
+			t.Errorf("Expected _id from the document to be used, got %q", id)
+		}
+		assertNoSourceID(t, body.Bytes())
+	})
+}
+
+// assertNoSourceID fails the test if the marshaled document contains an _id field
+func assertNoSourceID(t *testing.T, body []byte) {
+	t.Helper()
+
+	var source map[string]any
+	if err := json.Unmarshal(body, &source); err != nil {
+		t.Fatalf("Failed to decode document body: %v", err)
+	}
+	if _, exists := source["_id"]; exists {
+		t.Errorf("Expected no _id in document source, got %v", source)
+	}
+	if source["name"] != "test" {
+		t.Errorf("Expected document fields to be preserved, got %v", source)
+	}
+}
+
 func TestIDModeValidation(t *testing.T) {
 	tests := []struct {
 		mode  string
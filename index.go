@@ -45,16 +45,18 @@ func (idx *Index) IndexMany(ctx context.Context, documents []map[string]any) (*B
 
 	// Build bulk operations
 	operations := make([]map[string]any, 0, len(documents)*2)
-	for _, doc := range documents {
+	for i, doc := range documents {
+		if err := idx.client.validateDocument(doc); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
 		// Enhance document
 		enhanced := idx.client.enhanceDocument(doc)
 
-		// Get or generate ID
-		var docID string
-		if id, exists := enhanced["_id"]; exists {
-			if idStr, ok := id.(string); ok {
-				docID = idStr
-			}
+		// Get or generate ID, keeping it out of _source
+		docID, err := takeDocumentID(enhanced)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
 		}
 
 		// Add index operation
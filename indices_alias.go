@@ -0,0 +1,137 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// AliasOptions configures a filtered or routing-bound alias
+type AliasOptions struct {
+	Filter        *query.Builder // Only documents matching the filter are visible through the alias (e.g. per-tenant aliases)
+	Routing       string         // Routing value used for both searches and indexing through the alias
+	SearchRouting string         // Routing value(s) for searches, comma separated; overrides Routing for searches
+	IndexRouting  string         // Routing value for indexing; overrides Routing for indexing
+	IsWriteIndex  *bool          // Marks the index as the alias write target; required when several indices back a rollover alias
+}
+
+// AliasWithOptions creates or updates an alias with a filter and/or routing pointing to one or more indices
+func (s *IndicesService) AliasWithOptions(ctx context.Context, aliasName string, options AliasOptions, indexNames ...string) error {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	if len(indexNames) == 0 {
+		return fmt.Errorf("at least one index name must be provided")
+	}
+	if options.IsWriteIndex != nil && *options.IsWriteIndex && len(indexNames) > 1 {
+		return fmt.Errorf("alias '%s' can only have one write index, got %d indices", aliasName, len(indexNames))
+	}
+
+	// Build alias actions
+	actions := make([]map[string]any, 0, len(indexNames))
+	for _, indexName := range indexNames {
+		actions = append(actions, map[string]any{
+			"add": buildAliasAction(indexName, aliasName, options),
+		})
+	}
+
+	return s.updateAliases(ctx, aliasName, actions)
+}
+
+// SetWriteIndex atomically makes writeIndex the write index of an alias and adds readIndices to the
+// alias as read-only members, e.g. after a manual rollover. Writes through an alias backed by several
+// indices fail unless exactly one of them is the write index.
+func (s *IndicesService) SetWriteIndex(ctx context.Context, aliasName, writeIndex string, readIndices ...string) error {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	isWriteIndex, notWriteIndex := true, false
+
+	actions := make([]map[string]any, 0, len(readIndices)+1)
+	actions = append(actions, map[string]any{
+		"add": buildAliasAction(writeIndex, aliasName, AliasOptions{IsWriteIndex: &isWriteIndex}),
+	})
+	for _, indexName := range readIndices {
+		actions = append(actions, map[string]any{
+			"add": buildAliasAction(indexName, aliasName, AliasOptions{IsWriteIndex: &notWriteIndex}),
+		})
+	}
+
+	return s.updateAliases(ctx, aliasName, actions)
+}
+
+// updateAliases applies alias actions atomically
+func (s *IndicesService) updateAliases(ctx context.Context, aliasName string, actions []map[string]any) error {
+	if err := s.client.checkWritable("update aliases"); err != nil {
+		return err
+	}
+
+	aliasBody := map[string]any{
+		"actions": actions,
+	}
+
+	bodyBytes, err := json.Marshal(aliasBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias body: %w", err)
+	}
+
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, s.client.client)
+	if err != nil {
+		return fmt.Errorf("failed to update aliases: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.client.config.Logger.Warn("Failed to close response body - error: %s",
+				err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to update alias '%s': %s - %s", aliasName, res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// buildAliasAction builds the body of an "add" alias action
+func buildAliasAction(indexName, aliasName string, options AliasOptions) map[string]any {
+	action := map[string]any{
+		"index": indexName,
+		"alias": aliasName,
+	}
+
+	if options.Filter != nil {
+		action["filter"] = options.Filter.Build()
+	}
+	if options.Routing != "" {
+		action["routing"] = options.Routing
+	}
+	if options.SearchRouting != "" {
+		action["search_routing"] = options.SearchRouting
+	}
+	if options.IndexRouting != "" {
+		action["index_routing"] = options.IndexRouting
+	}
+	if options.IsWriteIndex != nil {
+		action["is_write_index"] = *options.IsWriteIndex
+	}
+
+	return action
+}
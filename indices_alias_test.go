@@ -0,0 +1,45 @@
+package elastic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetWriteIndex(t *testing.T) {
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	if err := client.Indices().SetWriteIndex(context.Background(), "logs", "logs-000002", "logs-000001"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"actions":[` +
+		`{"add":{"alias":"logs","index":"logs-000002","is_write_index":true}},` +
+		`{"add":{"alias":"logs","index":"logs-000001","is_write_index":false}}]}`
+	if requestBody != expected {
+		t.Errorf("Expected body %s, got %s", expected, requestBody)
+	}
+}
+
+func TestAliasWithOptionsSingleWriteIndex(t *testing.T) {
+	client := &Client{config: &Config{Logger: &NopLogger{}}}
+	isWriteIndex := true
+
+	err := client.Indices().AliasWithOptions(context.Background(), "logs", AliasOptions{IsWriteIndex: &isWriteIndex}, "logs-1", "logs-2")
+	if err == nil {
+		t.Error("Expected an error when marking several indices as write index")
+	}
+}
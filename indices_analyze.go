@@ -0,0 +1,183 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// AnalyzeOptions describes an analyze request. Use either a named Analyzer, a Field (analyzed with
+// the field's mapping), or an ad-hoc chain of CharFilter, Tokenizer and Filter components; each
+// component is a built-in name like "lowercase" or an inline definition map.
+type AnalyzeOptions struct {
+	Text       []string // Text to analyze; several values are analyzed as a multi-valued field
+	Analyzer   string   // Named analyzer, e.g. "standard" or one defined in the index settings
+	Field      string   // Analyze with the analyzer mapped for this field (requires an index)
+	Tokenizer  any      // Tokenizer name or definition for an ad-hoc analyzer
+	Filter     []any    // Token filter names or definitions, applied in order
+	CharFilter []any    // Character filter names or definitions, applied before the tokenizer
+	Normalizer string   // Named normalizer, producing a single token
+	Explain    bool     // Return the output of every analysis step in AnalyzeResponse.Detail
+	Attributes []string // With Explain, only return these token attributes
+}
+
+// Validate checks that the options describe a single, consistent analysis chain
+func (o AnalyzeOptions) Validate(indexName string) error {
+	if len(o.Text) == 0 {
+		return fmt.Errorf("analyze requires at least one text")
+	}
+	if o.Analyzer != "" && (o.Tokenizer != nil || len(o.Filter) > 0 || len(o.CharFilter) > 0) {
+		return fmt.Errorf("analyze cannot combine a named analyzer with tokenizer or filters")
+	}
+	if o.Analyzer != "" && o.Normalizer != "" {
+		return fmt.Errorf("analyze cannot combine an analyzer with a normalizer")
+	}
+	if o.Field != "" && indexName == "" {
+		return fmt.Errorf("analyzing with field '%s' requires an index", o.Field)
+	}
+	if len(o.Attributes) > 0 && !o.Explain {
+		return fmt.Errorf("analyze attributes require explain")
+	}
+	return nil
+}
+
+// body builds the _analyze request body
+func (o AnalyzeOptions) body() map[string]any {
+	body := map[string]any{
+		"text": o.Text,
+	}
+	if o.Analyzer != "" {
+		body["analyzer"] = o.Analyzer
+	}
+	if o.Field != "" {
+		body["field"] = o.Field
+	}
+	if o.Tokenizer != nil {
+		body["tokenizer"] = o.Tokenizer
+	}
+	if len(o.Filter) > 0 {
+		body["filter"] = o.Filter
+	}
+	if len(o.CharFilter) > 0 {
+		body["char_filter"] = o.CharFilter
+	}
+	if o.Normalizer != "" {
+		body["normalizer"] = o.Normalizer
+	}
+	if o.Explain {
+		body["explain"] = true
+	}
+	if len(o.Attributes) > 0 {
+		body["attributes"] = o.Attributes
+	}
+	return body
+}
+
+// AnalyzeToken is a single token produced by analysis
+type AnalyzeToken struct {
+	Token       string `json:"token"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+}
+
+// AnalyzeTokenList holds the tokens produced by one analysis step
+type AnalyzeTokenList struct {
+	Name   string         `json:"name"`
+	Tokens []AnalyzeToken `json:"tokens"`
+}
+
+// AnalyzeCharFilter holds the text produced by a character filter
+type AnalyzeCharFilter struct {
+	Name         string   `json:"name"`
+	FilteredText []string `json:"filtered_text"`
+}
+
+// AnalyzeDetail is the step-by-step output of an explained analysis
+type AnalyzeDetail struct {
+	CustomAnalyzer bool                `json:"custom_analyzer"`
+	Analyzer       *AnalyzeTokenList   `json:"analyzer,omitempty"` // Set for built-in analyzers that can't be broken down
+	CharFilters    []AnalyzeCharFilter `json:"charfilters,omitempty"`
+	Tokenizer      *AnalyzeTokenList   `json:"tokenizer,omitempty"`
+	TokenFilters   []AnalyzeTokenList  `json:"tokenfilters,omitempty"`
+}
+
+// AnalyzeResponse represents the result of an analyze request
+type AnalyzeResponse struct {
+	Tokens []AnalyzeToken `json:"tokens,omitempty"`
+	Detail *AnalyzeDetail `json:"detail,omitempty"` // Set when Explain was requested
+}
+
+// Terms returns the final tokens as strings
+func (r *AnalyzeResponse) Terms() []string {
+	tokens := r.Tokens
+	if r.Detail != nil {
+		switch {
+		case len(r.Detail.TokenFilters) > 0:
+			tokens = r.Detail.TokenFilters[len(r.Detail.TokenFilters)-1].Tokens
+		case r.Detail.Tokenizer != nil:
+			tokens = r.Detail.Tokenizer.Tokens
+		case r.Detail.Analyzer != nil:
+			tokens = r.Detail.Analyzer.Tokens
+		}
+	}
+
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.Token
+	}
+	return terms
+}
+
+// AnalyzeWithOptions analyzes text with a named analyzer, a field's analyzer or an ad-hoc chain of
+// components. indexName may be empty to use only built-in components.
+func (s *IndicesService) AnalyzeWithOptions(ctx context.Context, indexName string, options AnalyzeOptions) (*AnalyzeResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	if err := options.Validate(indexName); err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := json.Marshal(options.body())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal analyze body: %w", err)
+	}
+
+	req := esapi.IndicesAnalyzeRequest{
+		Index: indexName,
+		Body:  bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, s.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze text: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.client.config.Logger.Warn("Failed to close response body - error: %s",
+				err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to analyze text in index '%s': %s - %s", indexName, res.Status(), string(bodyBytes))
+	}
+
+	var response AnalyzeResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode analyze response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,76 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAnalyzeOptionsBody(t *testing.T) {
+	options := AnalyzeOptions{
+		Text:       []string{"<b>Quick</b> Foxes"},
+		Tokenizer:  "standard",
+		Filter:     []any{"lowercase", map[string]any{"type": "stop", "stopwords": []string{"a"}}},
+		CharFilter: []any{"html_strip"},
+		Explain:    true,
+	}
+	if err := options.Validate(""); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	body := options.body()
+	if body["tokenizer"] != "standard" || body["explain"] != true {
+		t.Errorf("Unexpected analyze body: %v", body)
+	}
+	if filters, ok := body["filter"].([]any); !ok || len(filters) != 2 {
+		t.Errorf("Expected 2 filters, got %v", body["filter"])
+	}
+	if _, ok := body["analyzer"]; ok {
+		t.Error("Expected no analyzer for an ad-hoc chain")
+	}
+
+	invalid := []struct {
+		options AnalyzeOptions
+		index   string
+	}{
+		{AnalyzeOptions{}, ""},
+		{AnalyzeOptions{Text: []string{"x"}, Analyzer: "standard", Tokenizer: "whitespace"}, ""},
+		{AnalyzeOptions{Text: []string{"x"}, Field: "title"}, ""},
+		{AnalyzeOptions{Text: []string{"x"}, Attributes: []string{"keyword"}}, ""},
+	}
+	for _, tt := range invalid {
+		if err := tt.options.Validate(tt.index); err == nil {
+			t.Errorf("Expected validation error for %+v", tt.options)
+		}
+	}
+}
+
+func TestAnalyzeResponseTerms(t *testing.T) {
+	raw := `{"detail": {
+		"custom_analyzer": true,
+		"charfilters": [{"name": "html_strip", "filtered_text": ["Quick Foxes"]}],
+		"tokenizer": {"name": "standard", "tokens": [
+			{"token": "Quick", "start_offset": 3, "end_offset": 8, "type": "<ALPHANUM>", "position": 0},
+			{"token": "Foxes", "start_offset": 13, "end_offset": 18, "type": "<ALPHANUM>", "position": 1}
+		]},
+		"tokenfilters": [{"name": "lowercase", "tokens": [
+			{"token": "quick", "start_offset": 3, "end_offset": 8, "type": "<ALPHANUM>", "position": 0},
+			{"token": "foxes", "start_offset": 13, "end_offset": 18, "type": "<ALPHANUM>", "position": 1}
+		]}]
+	}}`
+
+	var response AnalyzeResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode analyze response: %v", err)
+	}
+
+	terms := response.Terms()
+	if len(terms) != 2 || terms[0] != "quick" || terms[1] != "foxes" {
+		t.Errorf("Expected final tokens [quick foxes], got %v", terms)
+	}
+	if response.Detail.CharFilters[0].FilteredText[0] != "Quick Foxes" {
+		t.Errorf("Unexpected char filter output: %+v", response.Detail.CharFilters)
+	}
+	if response.Detail.Tokenizer.Tokens[0].StartOffset != 3 {
+		t.Errorf("Expected tokenizer offsets to be decoded, got %+v", response.Detail.Tokenizer.Tokens[0])
+	}
+}
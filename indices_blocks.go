@@ -0,0 +1,56 @@
+package elastic
+
+import (
+	"context"
+	"fmt"
+)
+
+// writeBlocks lists the index.blocks.* settings that reject writes, most specific first,
+// with the reason reported by IsWriteBlocked
+var writeBlocks = []struct {
+	setting string
+	reason  string
+}{
+	{"read_only_allow_delete", "index.blocks.read_only_allow_delete is set, usually by the flood-stage disk watermark; free disk space, then call ClearReadOnlyBlock"},
+	{"read_only", "index.blocks.read_only is set; the index and its metadata are read-only"},
+	{"write", "index.blocks.write is set; write operations are blocked"},
+}
+
+// IsWriteBlocked reports whether writes to the index are blocked by one of the index.blocks.*
+// settings, including the read_only_allow_delete block Elasticsearch applies when the
+// flood-stage disk watermark is exceeded. The returned reason describes the block.
+func (ir *IndexResource) IsWriteBlocked(ctx context.Context) (bool, string, error) {
+	settings, err := ir.Settings().Get(ctx)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read blocks of index '%s': %w", ir.name, err)
+	}
+
+	indexSettings, _ := settings["index"].(map[string]any)
+	blocks, _ := indexSettings["blocks"].(map[string]any)
+	for _, block := range writeBlocks {
+		if fmt.Sprint(blocks[block.setting]) == "true" {
+			return true, block.reason, nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// ClearReadOnlyBlock removes the read_only and read_only_allow_delete blocks from the index, e.g.
+// after disk space was freed following a flood-stage watermark. The write block is left in place.
+func (ir *IndexResource) ClearReadOnlyBlock(ctx context.Context) error {
+	err := ir.Settings().Update(ctx, map[string]any{
+		"index": map[string]any{
+			"blocks": map[string]any{
+				"read_only":              nil,
+				"read_only_allow_delete": nil,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear read-only block of index '%s': %w", ir.name, err)
+	}
+
+	ir.client.config.Logger.Info("Read-only block cleared - index: %s", ir.name)
+	return nil
+}
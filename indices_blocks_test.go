@@ -0,0 +1,76 @@
+package elastic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsWriteBlocked(t *testing.T) {
+	settings := map[string]string{
+		"logs":    `{"logs": {"settings": {"index": {"number_of_replicas": "1"}}}}`,
+		"flooded": `{"flooded": {"settings": {"index": {"blocks": {"read_only_allow_delete": "true", "write": "true"}}}}}`,
+		"frozen":  `{"frozen": {"settings": {"index": {"blocks": {"write": "true"}}}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(settings[strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/_settings")]))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	blocked, reason, err := client.Indices().Get("logs").IsWriteBlocked(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if blocked || reason != "" {
+		t.Errorf("Expected index without blocks to be writable, got blocked=%v reason=%q", blocked, reason)
+	}
+
+	blocked, reason, err = client.Indices().Get("flooded").IsWriteBlocked(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !blocked || !strings.Contains(reason, "read_only_allow_delete") {
+		t.Errorf("Expected the disk flood block to be reported, got blocked=%v reason=%q", blocked, reason)
+	}
+
+	blocked, reason, err = client.Indices().Get("frozen").IsWriteBlocked(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !blocked || !strings.Contains(reason, "index.blocks.write") {
+		t.Errorf("Expected the write block to be reported, got blocked=%v reason=%q", blocked, reason)
+	}
+}
+
+func TestClearReadOnlyBlock(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(bodyBytes)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	if err := client.Indices().Get("flooded").ClearReadOnlyBlock(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if method != http.MethodPut || path != "/flooded/_settings" {
+		t.Errorf("Expected PUT /flooded/_settings, got %s %s", method, path)
+	}
+	expected := `{"index":{"blocks":{"read_only":null,"read_only_allow_delete":null}}}`
+	if body != expected {
+		t.Errorf("Expected body %s, got %s", expected, body)
+	}
+}
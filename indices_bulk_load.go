@@ -0,0 +1,119 @@
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BulkLoadOption configures WithBulkLoadSettings
+type BulkLoadOption func(*bulkLoadOptions)
+
+// bulkLoadOptions holds the resolved bulk load options
+type bulkLoadOptions struct {
+	forceMerge     bool
+	maxNumSegments int
+}
+
+// WithForceMergeAfterLoad force merges the index down to maxNumSegments (0 lets Elasticsearch
+// decide) once the load succeeded, before replicas are restored so they copy the merged segments
+func WithForceMergeAfterLoad(maxNumSegments int) BulkLoadOption {
+	return func(opts *bulkLoadOptions) {
+		opts.forceMerge = true
+		opts.maxNumSegments = maxNumSegments
+	}
+}
+
+// WithBulkLoadSettings runs load with the index tuned for bulk indexing: refresh_interval -1 and
+// number_of_replicas 0. Afterwards the index is refreshed (and force merged when requested), and the
+// original settings are restored even when load fails or panics, or ctx is cancelled.
+func (ir *IndexResource) WithBulkLoadSettings(ctx context.Context, load func() error, options ...BulkLoadOption) (err error) {
+	if ctx == nil {
+		// No default timeout: the load itself may take arbitrarily long
+		ctx = context.Background()
+	}
+
+	var opts bulkLoadOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	settings := ir.Settings()
+
+	refreshInterval, err := settings.GetRefreshInterval(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read refresh interval of index '%s': %w", ir.name, err)
+	}
+	replicas, err := settings.GetNumberOfReplicas(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read replicas of index '%s': %w", ir.name, err)
+	}
+
+	err = settings.Update(ctx, map[string]any{
+		"index": map[string]any{
+			"refresh_interval":   "-1",
+			"number_of_replicas": 0,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply bulk load settings to index '%s': %w", ir.name, err)
+	}
+
+	ir.client.config.Logger.Info("Bulk load settings applied - index: %s, original_refresh_interval: %s, original_replicas: %d", ir.name, refreshInterval, replicas)
+
+	loaded := false
+	defer func() {
+		if finishErr := ir.finishBulkLoad(ctx, refreshInterval, replicas, loaded, opts); finishErr != nil {
+			if err == nil {
+				err = finishErr
+			} else {
+				ir.client.config.Logger.Error("Failed to finish bulk load - index: %s, error: %s", ir.name, finishErr.Error())
+			}
+		}
+	}()
+
+	if err = load(); err != nil {
+		return err
+	}
+	loaded = true
+
+	return nil
+}
+
+// finishBulkLoad refreshes and optionally force merges a successfully loaded index, then restores
+// its original settings. Restoring uses a detached context so a cancelled load still restores them.
+func (ir *IndexResource) finishBulkLoad(ctx context.Context, refreshInterval string, replicas int, loaded bool, opts bulkLoadOptions) error {
+	var finishErr error
+
+	if loaded {
+		if err := ir.Refresh(ctx); err != nil {
+			finishErr = fmt.Errorf("failed to refresh index '%s' after bulk load: %w", ir.name, err)
+		} else if opts.forceMerge {
+			if err := ir.ForceMerge(ctx, opts.maxNumSegments); err != nil {
+				finishErr = fmt.Errorf("failed to force merge index '%s' after bulk load: %w", ir.name, err)
+			}
+		}
+	}
+
+	restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	// An empty refresh interval means it wasn't set on the index; null resets it to the default
+	var originalRefreshInterval any
+	if refreshInterval != "" {
+		originalRefreshInterval = refreshInterval
+	}
+
+	err := ir.Settings().Update(restoreCtx, map[string]any{
+		"index": map[string]any{
+			"refresh_interval":   originalRefreshInterval,
+			"number_of_replicas": replicas,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore settings of index '%s' after bulk load (refresh_interval: %q, number_of_replicas: %d): %w", ir.name, refreshInterval, replicas, err)
+	}
+
+	ir.client.config.Logger.Info("Bulk load settings restored - index: %s", ir.name)
+	return finishErr
+}
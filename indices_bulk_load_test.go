@@ -0,0 +1,102 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// bulkLoadTestServer records the requests made against a single index named "products"
+func bulkLoadTestServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+r.URL.RawQuery+" "+string(body)))
+		mu.Unlock()
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/products/_settings" {
+			_, _ = w.Write([]byte(`{"products": {"settings": {"index": {"number_of_replicas": "2", "refresh_interval": "5s"}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestWithBulkLoadSettings(t *testing.T) {
+	server, requests := bulkLoadTestServer(t)
+	client := newTestServerClient(t, server)
+
+	loadCalled := false
+	err := client.Indices().Get("products").WithBulkLoadSettings(context.Background(), func() error {
+		loadCalled = true
+		return nil
+	}, WithForceMergeAfterLoad(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !loadCalled {
+		t.Fatal("Expected the load function to be called")
+	}
+
+	expected := []string{
+		"GET /products/_settings",
+		"GET /products/_settings",
+		`PUT /products/_settings  {"index":{"number_of_replicas":0,"refresh_interval":"-1"}}`,
+		"POST /products/_refresh",
+		"POST /products/_forcemerge max_num_segments=1",
+		`PUT /products/_settings  {"index":{"number_of_replicas":2,"refresh_interval":"5s"}}`,
+	}
+	got := requests()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected requests %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Request %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestWithBulkLoadSettingsRestoresOnFailure(t *testing.T) {
+	server, requests := bulkLoadTestServer(t)
+	client := newTestServerClient(t, server)
+
+	loadErr := errors.New("load failed")
+	err := client.Indices().Get("products").WithBulkLoadSettings(context.Background(), func() error {
+		return loadErr
+	}, WithForceMergeAfterLoad(1))
+	if !errors.Is(err, loadErr) {
+		t.Fatalf("Expected the load error, got %v", err)
+	}
+
+	got := requests()
+	last := got[len(got)-1]
+	if last != `PUT /products/_settings  {"index":{"number_of_replicas":2,"refresh_interval":"5s"}}` {
+		t.Errorf("Expected original settings to be restored last, got %q", last)
+	}
+	for _, request := range got {
+		if strings.Contains(request, "_refresh") || strings.Contains(request, "_forcemerge") {
+			t.Errorf("Expected no refresh or force merge after a failed load, got %q", request)
+		}
+	}
+}
@@ -66,6 +66,10 @@ func (im *IndexMapping) Get(ctx context.Context) (map[string]any, error) {
 
 // Update updates the index mapping
 func (im *IndexMapping) Update(ctx context.Context, mapping map[string]any) error {
+	if err := im.client.checkWritable("update mapping"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -120,7 +124,8 @@ func (im *IndexMapping) Create(ctx context.Context, mapping map[string]any) erro
 	}
 
 	// Create index with mapping
-	return im.client.Indices().Create(ctx, im.indexName, mapping)
+	_, err = im.client.Indices().Create(ctx, im.indexName, mapping)
+	return err
 }
 
 // GetField retrieves the mapping for a specific field
@@ -154,3 +159,54 @@ func (im *IndexMapping) AddField(ctx context.Context, fieldName string, fieldMap
 
 	return im.Update(ctx, updateMapping)
 }
+
+// IndexMappings represents the mapping definition of a single index
+type IndexMappings struct {
+	Mappings map[string]any `json:"mappings"`
+}
+
+// Properties returns the top-level field definitions of the mapping
+func (m IndexMappings) Properties() map[string]any {
+	if properties, ok := m.Mappings["properties"].(map[string]any); ok {
+		return properties
+	}
+	return map[string]any{}
+}
+
+// GetMappings retrieves the mappings of one or more indices, aliases or patterns
+// The result is keyed by concrete index name, so an alias backed by several indices
+// returns one entry per backing index
+func (s *IndicesService) GetMappings(ctx context.Context, indices ...string) (map[string]IndexMappings, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	req := esapi.IndicesGetMappingRequest{
+		Index: indices, // Empty slice means all indices
+	}
+
+	res, err := req.Do(ctx, s.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index mappings: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.client.config.Logger.Warn("Failed to close response body - error: %s",
+				err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to get mappings: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var result map[string]IndexMappings
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode mapping response: %w", err)
+	}
+
+	return result, nil
+}
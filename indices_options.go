@@ -0,0 +1,44 @@
+package elastic
+
+import "strings"
+
+// IndicesOption configures how index names and wildcard expressions are resolved
+type IndicesOption func(*indicesOptions)
+
+// indicesOptions holds the resolved index-resolution options for a request
+type indicesOptions struct {
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+	expandWildcards   string
+}
+
+// WithIgnoreUnavailable ignores missing or closed indices instead of failing the request
+func WithIgnoreUnavailable(ignore bool) IndicesOption {
+	return func(o *indicesOptions) {
+		o.ignoreUnavailable = &ignore
+	}
+}
+
+// WithAllowNoIndices controls whether a wildcard expression that matches no indices is an error
+func WithAllowNoIndices(allow bool) IndicesOption {
+	return func(o *indicesOptions) {
+		o.allowNoIndices = &allow
+	}
+}
+
+// WithExpandWildcards sets which index states wildcard expressions match
+// (any of "open", "closed", "hidden", "none", "all")
+func WithExpandWildcards(states ...string) IndicesOption {
+	return func(o *indicesOptions) {
+		o.expandWildcards = strings.Join(states, ",")
+	}
+}
+
+// buildIndicesOptions applies the given options
+func buildIndicesOptions(options []IndicesOption) indicesOptions {
+	var opts indicesOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
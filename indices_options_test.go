@@ -0,0 +1,85 @@
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBuildIndicesOptions(t *testing.T) {
+	opts := buildIndicesOptions([]IndicesOption{
+		WithIgnoreUnavailable(true),
+		WithAllowNoIndices(false),
+		WithExpandWildcards("open", "closed"),
+	})
+
+	if opts.ignoreUnavailable == nil || !*opts.ignoreUnavailable {
+		t.Error("Expected ignoreUnavailable to be true")
+	}
+	if opts.allowNoIndices == nil || *opts.allowNoIndices {
+		t.Error("Expected allowNoIndices to be false")
+	}
+	if opts.expandWildcards != "open,closed" {
+		t.Errorf("Expected expandWildcards 'open,closed', got '%s'", opts.expandWildcards)
+	}
+
+	if empty := buildIndicesOptions(nil); empty.ignoreUnavailable != nil || empty.allowNoIndices != nil {
+		t.Error("Expected unset options to stay nil so server defaults apply")
+	}
+}
+
+func TestIndexPatternIndices(t *testing.T) {
+	pattern := (&IndicesService{}).Pattern("logs-2023-*, metrics-2023-*,")
+
+	indices := pattern.indices()
+	if len(indices) != 2 || indices[0] != "logs-2023-*" || indices[1] != "metrics-2023-*" {
+		t.Errorf("Expected [logs-2023-* metrics-2023-*], got %v", indices)
+	}
+}
+
+func TestIndexPatternDeleteResolvesNames(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/_resolve/index/logs-2023-*":
+			if r.URL.Query().Get("allow_no_indices") != "true" {
+				t.Errorf("Expected allow_no_indices=true, got %q", r.URL.Query().Get("allow_no_indices"))
+			}
+			_, _ = w.Write([]byte(`{"indices": [
+				{"name": "logs-2023-01", "attributes": ["open"]},
+				{"name": "logs-2023-02", "attributes": ["closed"]},
+				{"name": ".ds-logs-2023-stream-000001", "attributes": ["open"], "data_stream": "logs-2023-stream"}
+			], "aliases": [], "data_streams": [{"name": "logs-2023-stream"}]}`))
+		case r.URL.Path == "/_resolve/index/metrics-1999-*":
+			_, _ = w.Write([]byte(`{"indices": [], "aliases": [], "data_streams": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	ctx := context.Background()
+
+	if err := client.Indices().Pattern("logs-2023-*").Delete(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"GET /_resolve/index/logs-2023-*", "DELETE /logs-2023-01,logs-2023-02"}
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+
+	requests = nil
+	if err := client.Indices().Pattern("metrics-1999-*").Delete(ctx); err != nil {
+		t.Fatalf("Expected no error when nothing matches, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the resolve request when nothing matches, got %v", requests)
+	}
+}
@@ -0,0 +1,153 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// patternDeleteBatchSize caps the index names per delete request, keeping the URL short
+const patternDeleteBatchSize = 50
+
+// IndexPattern provides operations on all indices matching a wildcard expression
+type IndexPattern struct {
+	client  *Client
+	pattern string
+	options indicesOptions
+}
+
+// Pattern returns the index pattern
+func (ip *IndexPattern) Pattern() string {
+	return ip.pattern
+}
+
+// Delete deletes all indices matching the pattern. The pattern is resolved to concrete index names
+// first, since clusters with action.destructive_requires_name=true (the default since 8.0) reject
+// wildcard deletes. Backing indices of data streams are skipped; delete the data stream instead.
+// When no index matches, nothing is deleted and no error is returned.
+func (ip *IndexPattern) Delete(ctx context.Context) error {
+	if err := ip.client.checkWritable("delete index"); err != nil {
+		return err
+	}
+
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	names, err := ip.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		ip.client.config.Logger.Info("No indices match pattern, nothing to delete - pattern: %s", ip.pattern)
+		return nil
+	}
+
+	// An index removed between resolving and deleting is already gone, which is fine
+	opts := ip.options
+	if opts.ignoreUnavailable == nil {
+		ignoreUnavailable := true
+		opts.ignoreUnavailable = &ignoreUnavailable
+	}
+
+	for start := 0; start < len(names); start += patternDeleteBatchSize {
+		end := min(start+patternDeleteBatchSize, len(names))
+		indexResource := &IndexResource{
+			client: ip.client,
+			name:   strings.Join(names[start:end], ","),
+		}
+		if err := indexResource.delete(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the concrete names of the indices matching the pattern, excluding data stream
+// backing indices. A pattern matching nothing resolves to no names unless WithAllowNoIndices(false) is set.
+func (ip *IndexPattern) resolve(ctx context.Context) ([]string, error) {
+	allowNoIndices := ip.options.allowNoIndices
+	if allowNoIndices == nil {
+		allow := true
+		allowNoIndices = &allow
+	}
+
+	req := esapi.IndicesResolveIndexRequest{
+		Name:              ip.indices(),
+		AllowNoIndices:    allowNoIndices,
+		ExpandWildcards:   ip.options.expandWildcards,
+		IgnoreUnavailable: ip.options.ignoreUnavailable,
+	}
+
+	res, err := req.Do(ctx, ip.client.client)
+	if err != nil {
+		ip.client.config.Logger.Error("Failed to resolve index pattern - pattern: %s, error: %s", ip.pattern, err.Error())
+		return nil, fmt.Errorf("failed to resolve index pattern: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			ip.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to resolve index pattern '%s': %s - %s", ip.pattern, res.Status(), string(bodyBytes))
+	}
+
+	var resolved struct {
+		Indices []struct {
+			Name       string `json:"name"`
+			DataStream string `json:"data_stream"`
+		} `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resolved); err != nil {
+		return nil, fmt.Errorf("failed to decode resolve index response: %w", err)
+	}
+
+	names := make([]string, 0, len(resolved.Indices))
+	for _, index := range resolved.Indices {
+		if index.DataStream == "" {
+			names = append(names, index.Name)
+		}
+	}
+
+	ip.client.config.Logger.Debug("Index pattern resolved - pattern: %s, indices: %d", ip.pattern, len(names))
+
+	return names, nil
+}
+
+// Refresh forces a refresh of all indices matching the pattern
+func (ip *IndexPattern) Refresh(ctx context.Context) error {
+	return ip.client.Indices().refresh(ctx, ip.indices(), ip.options)
+}
+
+// Flush forces a flush of all indices matching the pattern
+func (ip *IndexPattern) Flush(ctx context.Context) error {
+	return ip.client.Indices().flush(ctx, ip.indices(), ip.options)
+}
+
+// Stats returns statistics for all indices matching the pattern
+func (ip *IndexPattern) Stats(ctx context.Context) (*IndexStats, error) {
+	return ip.client.Indices().stats(ctx, ip.indices(), ip.options)
+}
+
+// indices splits a comma-separated pattern into individual expressions
+func (ip *IndexPattern) indices() []string {
+	parts := strings.Split(ip.pattern, ",")
+	indices := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			indices = append(indices, part)
+		}
+	}
+	return indices
+}
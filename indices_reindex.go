@@ -0,0 +1,152 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// ReindexOptions configures a reindex with typed, validated settings
+type ReindexOptions struct {
+	Query     *query.Builder // Only copy matching documents (nil copies all)
+	OpType    string         // "index" (default, overwrite) or "create" (skip documents that already exist in the destination)
+	Conflicts string         // "abort" (default) or "proceed" (count version conflicts instead of failing)
+	MaxDocs   int            // Maximum number of documents to copy (0 = all)
+}
+
+// Validate checks that the options hold supported values
+func (o ReindexOptions) Validate() error {
+	switch o.OpType {
+	case "", "index", "create":
+	default:
+		return fmt.Errorf("invalid reindex op_type '%s': must be 'index' or 'create'", o.OpType)
+	}
+
+	switch o.Conflicts {
+	case "", "abort", "proceed":
+	default:
+		return fmt.Errorf("invalid reindex conflicts '%s': must be 'abort' or 'proceed'", o.Conflicts)
+	}
+
+	if o.MaxDocs < 0 {
+		return fmt.Errorf("reindex max_docs cannot be negative, got %d", o.MaxDocs)
+	}
+
+	return nil
+}
+
+// BulkItemError describes a document that failed during a reindex or by-query operation
+type BulkItemError struct {
+	Index  string     `json:"index"`
+	ID     string     `json:"id"`
+	Status int        `json:"status"`
+	Cause  ErrorCause `json:"cause"`
+}
+
+// ReindexResponse represents the result of a reindex
+type ReindexResponse struct {
+	Took             int64           `json:"took"`
+	TimedOut         bool            `json:"timed_out"`
+	Total            int64           `json:"total"`
+	Created          int64           `json:"created"`
+	Updated          int64           `json:"updated"`
+	Deleted          int64           `json:"deleted"`
+	Batches          int64           `json:"batches"`
+	VersionConflicts int64           `json:"version_conflicts"`
+	Noops            int64           `json:"noops"`
+	Failures         []BulkItemError `json:"failures"`
+}
+
+// ReindexWithOptions copies documents from a source index to a target index with typed options.
+// OpType "create" with Conflicts "proceed" makes an incremental reindex that skips documents
+// already present in the destination; they are counted in VersionConflicts.
+func (s *IndicesService) ReindexWithOptions(ctx context.Context, sourceIndex, targetIndex string, options ReindexOptions) (*ReindexResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Minute) // Longer timeout for reindex
+		defer cancel()
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.reindex(ctx, sourceIndex, targetIndex, buildReindexBody(sourceIndex, targetIndex, options))
+}
+
+// buildReindexBody builds the _reindex request body for the given options
+func buildReindexBody(sourceIndex, targetIndex string, options ReindexOptions) map[string]any {
+	source := map[string]any{
+		"index": sourceIndex,
+	}
+	if options.Query != nil {
+		source["query"] = options.Query.Build()
+	}
+
+	dest := map[string]any{
+		"index": targetIndex,
+	}
+	if options.OpType != "" {
+		dest["op_type"] = options.OpType
+	}
+
+	body := map[string]any{
+		"source": source,
+		"dest":   dest,
+	}
+	if options.Conflicts != "" {
+		body["conflicts"] = options.Conflicts
+	}
+	if options.MaxDocs > 0 {
+		body["max_docs"] = options.MaxDocs
+	}
+
+	return body
+}
+
+// reindex sends a _reindex request and decodes its result
+func (s *IndicesService) reindex(ctx context.Context, sourceIndex, targetIndex string, reindexBody map[string]any) (*ReindexResponse, error) {
+	if err := s.client.checkWritable("reindex"); err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := json.Marshal(reindexBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reindex body: %w", err)
+	}
+
+	req := esapi.ReindexRequest{
+		Body: bytes.NewReader(bodyBytes),
+	}
+
+	res, err := req.Do(ctx, s.client.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reindex: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.client.config.Logger.Warn("Failed to close response body - error: %s",
+				err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("failed to reindex from '%s' to '%s': %s - %s", sourceIndex, targetIndex, res.Status(), string(bodyBytes))
+	}
+
+	var response ReindexResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode reindex response: %w", err)
+	}
+
+	s.client.config.Logger.Info("Reindex completed - source: %s, target: %s, total: %d, created: %d, updated: %d, version_conflicts: %d", sourceIndex, targetIndex, response.Total, response.Created, response.Updated, response.VersionConflicts)
+
+	return &response, nil
+}
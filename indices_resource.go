@@ -22,28 +22,58 @@ func (ir *IndexResource) Name() string {
 	return ir.name
 }
 
-// Create creates the index with optional mapping
-func (ir *IndexResource) Create(ctx context.Context, mapping map[string]any) error {
+// CreateIndexOption configures index creation
+type CreateIndexOption func(*createIndexOptions)
+
+// createIndexOptions holds the resolved index creation options
+type createIndexOptions struct {
+	skipExistsCheck bool
+}
+
+// WithSkipExistsCheck sends the create request without checking whether the index exists first.
+// This halves the requests when provisioning many indices and avoids the race between the check
+// and the create; an existing index is still reported as ErrIndexAlreadyExists, from the server's
+// resource_already_exists_exception.
+func WithSkipExistsCheck() CreateIndexOption {
+	return func(opts *createIndexOptions) {
+		opts.skipExistsCheck = true
+	}
+}
+
+// Create creates the index with optional mapping. It fails with ErrIndexAlreadyExists if the index exists.
+// Check ShardsAcknowledged on the response before writing if the primary shards must be ready
+func (ir *IndexResource) Create(ctx context.Context, mapping map[string]any, options ...CreateIndexOption) (*CreateIndexResponse, error) {
+	if err := ir.client.checkWritable("create index"); err != nil {
+		return nil, err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 	}
 
-	// Check if index already exists
-	exists, err := ir.Exists(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to check if index exists: %w", err)
+	var opts createIndexOptions
+	for _, option := range options {
+		option(&opts)
 	}
-	if exists {
-		return fmt.Errorf("index '%s' already exists", ir.name)
+
+	// Check if index already exists
+	if !opts.skipExistsCheck {
+		exists, err := ir.Exists(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if index exists: %w", err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: '%s'", ErrIndexAlreadyExists, ir.name)
+		}
 	}
 
 	var body io.Reader
 	if mapping != nil {
 		bodyBytes, err := json.Marshal(mapping)
 		if err != nil {
-			return fmt.Errorf("failed to marshal mapping: %w", err)
+			return nil, fmt.Errorf("failed to marshal mapping: %w", err)
 		}
 		body = bytes.NewReader(bodyBytes)
 	}
@@ -56,7 +86,7 @@ func (ir *IndexResource) Create(ctx context.Context, mapping map[string]any) err
 	res, err := req.Do(ctx, ir.client.client)
 	if err != nil {
 		ir.client.config.Logger.Error("Failed to create index - index: %s, error: %s", ir.name, err.Error())
-		return fmt.Errorf("failed to create index: %w", err)
+		return nil, fmt.Errorf("failed to create index: %w", err)
 	}
 	defer func() {
 		if err := res.Body.Close(); err != nil {
@@ -66,17 +96,38 @@ func (ir *IndexResource) Create(ctx context.Context, mapping map[string]any) err
 
 	if res.IsError() {
 		bodyBytes, _ := io.ReadAll(res.Body)
+		if errorType(bodyBytes) == "resource_already_exists_exception" {
+			return nil, fmt.Errorf("%w: '%s'", ErrIndexAlreadyExists, ir.name)
+		}
 		ir.client.config.Logger.Error("Failed to create index - index: %s, status: %s, response: %s", ir.name, res.Status(), string(bodyBytes))
-		return fmt.Errorf("failed to create index '%s': %s - %s", ir.name, res.Status(), string(bodyBytes))
+		return nil, fmt.Errorf("failed to create index '%s': %s - %s", ir.name, res.Status(), string(bodyBytes))
+	}
+
+	var response CreateIndexResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode create index response: %w", err)
 	}
 
-	ir.client.config.Logger.Info("Index created successfully - index: %s", ir.name)
+	if !response.ShardsAcknowledged {
+		ir.client.config.Logger.Warn("Index created but shards not yet acknowledged - index: %s", ir.name)
+	}
 
-	return nil
+	ir.client.config.Logger.Info("Index created successfully - index: %s, acknowledged: %t, shards_acknowledged: %t", ir.name, response.Acknowledged, response.ShardsAcknowledged)
+
+	return &response, nil
 }
 
 // Delete deletes the index
-func (ir *IndexResource) Delete(ctx context.Context) error {
+func (ir *IndexResource) Delete(ctx context.Context, options ...IndicesOption) error {
+	return ir.delete(ctx, buildIndicesOptions(options))
+}
+
+// delete deletes the index using the given resolution options
+func (ir *IndexResource) delete(ctx context.Context, opts indicesOptions) error {
+	if err := ir.client.checkWritable("delete index"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -84,7 +135,10 @@ func (ir *IndexResource) Delete(ctx context.Context) error {
 	}
 
 	req := esapi.IndicesDeleteRequest{
-		Index: []string{ir.name},
+		Index:             []string{ir.name},
+		IgnoreUnavailable: opts.ignoreUnavailable,
+		AllowNoIndices:    opts.allowNoIndices,
+		ExpandWildcards:   opts.expandWildcards,
 	}
 
 	res, err := req.Do(ctx, ir.client.client)
@@ -109,6 +163,27 @@ func (ir *IndexResource) Delete(ctx context.Context) error {
 	return nil
 }
 
+// DeleteIfExists deletes the index and reports whether it existed.
+// A missing index is not an error, which makes the call safe for idempotent cleanup.
+// WithIgnoreUnavailable and WithAllowNoIndices are rejected, since they make a missing index
+// look deleted.
+func (ir *IndexResource) DeleteIfExists(ctx context.Context, options ...IndicesOption) (bool, error) {
+	opts := buildIndicesOptions(options)
+	if (opts.ignoreUnavailable != nil && *opts.ignoreUnavailable) || (opts.allowNoIndices != nil && *opts.allowNoIndices) {
+		return false, fmt.Errorf("delete index '%s': ignore_unavailable and allow_no_indices hide whether the index existed, use Delete instead", ir.name)
+	}
+
+	if err := ir.delete(ctx, opts); err != nil {
+		if IsIndexNotFoundError(err) {
+			ir.client.config.Logger.Debug("Index not found, nothing to delete - index: %s", ir.name)
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
 // Exists checks if the index exists
 func (ir *IndexResource) Exists(ctx context.Context) (bool, error) {
 	if ctx == nil {
@@ -192,6 +267,14 @@ func (ir *IndexResource) Count(ctx context.Context, query map[string]any) (int64
 	return idx.Count(ctx, query)
 }
 
+// TotalCount returns the total number of documents in this index (no query body is sent)
+func (ir *IndexResource) TotalCount(ctx context.Context) (int64, error) {
+	searchResource := &SearchResource{
+		client: ir.client,
+	}
+	return searchResource.CountAll(ctx, ir.name)
+}
+
 // Index settings helpers
 
 // DefaultIndexSettings returns commonly used index settings
@@ -225,8 +308,13 @@ func (ir *IndexResource) Flush(ctx context.Context) error {
 	return ir.client.Indices().Flush(ctx, ir.name)
 }
 
+// ForceMerge merges this index's segments down to maxNumSegments (0 lets Elasticsearch decide)
+func (ir *IndexResource) ForceMerge(ctx context.Context, maxNumSegments int) error {
+	return ir.client.Indices().ForceMerge(ctx, ir.name, maxNumSegments)
+}
+
 // Stats returns statistics for this index
-func (ir *IndexResource) Stats(ctx context.Context) (map[string]any, error) {
+func (ir *IndexResource) Stats(ctx context.Context) (*IndexStats, error) {
 	return ir.client.Indices().Stats(ctx, ir.name)
 }
 
@@ -240,6 +328,11 @@ func (ir *IndexResource) Reindex(ctx context.Context, targetIndex string, option
 	return ir.client.Indices().Reindex(ctx, ir.name, targetIndex, options...)
 }
 
+// ReindexWithOptions copies documents from this index to a target index with typed options
+func (ir *IndexResource) ReindexWithOptions(ctx context.Context, targetIndex string, options ReindexOptions) (*ReindexResponse, error) {
+	return ir.client.Indices().ReindexWithOptions(ctx, ir.name, targetIndex, options)
+}
+
 // Shrink reduces the number of shards in this index
 func (ir *IndexResource) Shrink(ctx context.Context, targetIndex string, targetShards int) error {
 	return ir.client.Indices().Shrink(ctx, ir.name, targetIndex, targetShards)
@@ -250,6 +343,11 @@ func (ir *IndexResource) Analyze(ctx context.Context, text, analyzer string) (ma
 	return ir.client.Indices().Analyze(ctx, ir.name, text, analyzer)
 }
 
+// AnalyzeWithOptions analyzes text in this index with an analyzer, a field or an ad-hoc component chain
+func (ir *IndexResource) AnalyzeWithOptions(ctx context.Context, options AnalyzeOptions) (*AnalyzeResponse, error) {
+	return ir.client.Indices().AnalyzeWithOptions(ctx, ir.name, options)
+}
+
 // Aliases returns all aliases pointing to this index
 func (ir *IndexResource) Aliases(ctx context.Context) (map[string]any, error) {
 	allAliases, err := ir.client.Indices().Aliases(ctx)
@@ -271,6 +369,11 @@ func (ir *IndexResource) AddAlias(ctx context.Context, aliasName string) error {
 	return ir.client.Indices().Alias(ctx, aliasName, ir.name)
 }
 
+// AddAliasWithOptions adds a filtered and/or routing-bound alias to this index
+func (ir *IndexResource) AddAliasWithOptions(ctx context.Context, aliasName string, options AliasOptions) error {
+	return ir.client.Indices().AliasWithOptions(ctx, aliasName, options, ir.name)
+}
+
 // RemoveAlias removes an alias from this index
 func (ir *IndexResource) RemoveAlias(ctx context.Context, aliasName string) error {
 	return ir.client.Indices().RemoveAlias(ctx, aliasName, ir.name)
@@ -280,3 +383,8 @@ func (ir *IndexResource) RemoveAlias(ctx context.Context, aliasName string) erro
 func (ir *IndexResource) Rollover(ctx context.Context, options ...map[string]any) (map[string]any, error) {
 	return ir.client.Indices().Rollover(ctx, ir.name, options...)
 }
+
+// RolloverWithConditions rolls this alias over when at least one condition is met
+func (ir *IndexResource) RolloverWithConditions(ctx context.Context, conditions RolloverConditions, options ...RolloverOption) (*RolloverResponse, error) {
+	return ir.client.Indices().RolloverWithConditions(ctx, ir.name, conditions, options...)
+}
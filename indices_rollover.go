@@ -0,0 +1,140 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// RolloverConditions are the conditions of which at least one must be met for a rollover to happen.
+// Zero values are omitted; with no conditions at all the rollover is unconditional.
+type RolloverConditions struct {
+	MaxAge              time.Duration // Maximum age of the current index, since its creation
+	MaxDocs             int64         // Maximum number of documents in the current index
+	MaxSize             string        // Maximum total primary size of the current index, e.g. "50gb"
+	MaxPrimaryShardSize string        // Maximum size of the largest primary shard, e.g. "50gb"
+}
+
+// toMap converts the conditions to the rollover "conditions" body section
+func (rc RolloverConditions) toMap() map[string]any {
+	conditions := map[string]any{}
+	if rc.MaxAge > 0 {
+		conditions["max_age"] = formatTimeValue(rc.MaxAge)
+	}
+	if rc.MaxDocs > 0 {
+		conditions["max_docs"] = rc.MaxDocs
+	}
+	if rc.MaxSize != "" {
+		conditions["max_size"] = rc.MaxSize
+	}
+	if rc.MaxPrimaryShardSize != "" {
+		conditions["max_primary_shard_size"] = rc.MaxPrimaryShardSize
+	}
+	return conditions
+}
+
+// RolloverResponse represents the result of a rollover request
+type RolloverResponse struct {
+	Acknowledged       bool            `json:"acknowledged"`
+	ShardsAcknowledged bool            `json:"shards_acknowledged"`
+	OldIndex           string          `json:"old_index"`
+	NewIndex           string          `json:"new_index"`
+	RolledOver         bool            `json:"rolled_over"`
+	DryRun             bool            `json:"dry_run"`
+	Conditions         map[string]bool `json:"conditions"` // Whether each condition was met, keyed like "[max_docs: 1000]"
+}
+
+// RolloverOption configures a typed rollover request
+type RolloverOption func(*rolloverOptions)
+
+// rolloverOptions holds the resolved rollover options
+type rolloverOptions struct {
+	dryRun bool
+}
+
+// WithRolloverDryRun only evaluates the conditions: the response reports whether the rollover
+// would happen (Conditions) without creating a new index
+func WithRolloverDryRun(dryRun bool) RolloverOption {
+	return func(opts *rolloverOptions) {
+		opts.dryRun = dryRun
+	}
+}
+
+// RolloverWithConditions rolls an alias over to a new index when at least one condition is met
+func (s *IndicesService) RolloverWithConditions(ctx context.Context, aliasName string, conditions RolloverConditions, options ...RolloverOption) (*RolloverResponse, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	var opts rolloverOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	rolloverBody := map[string]any{}
+	if conditionsMap := conditions.toMap(); len(conditionsMap) > 0 {
+		rolloverBody["conditions"] = conditionsMap
+	}
+
+	var response RolloverResponse
+	if err := s.rollover(ctx, aliasName, rolloverBody, opts.dryRun, &response); err != nil {
+		return nil, err
+	}
+
+	s.client.config.Logger.Info("Rollover evaluated - alias: %s, old_index: %s, new_index: %s, rolled_over: %t, dry_run: %t", aliasName, response.OldIndex, response.NewIndex, response.RolledOver, response.DryRun)
+
+	return &response, nil
+}
+
+// rollover sends a rollover request and decodes the response into result
+func (s *IndicesService) rollover(ctx context.Context, aliasName string, rolloverBody map[string]any, dryRun bool, result any) error {
+	if err := s.client.checkWritable("rollover"); err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if len(rolloverBody) > 0 {
+		bodyBytes, err := json.Marshal(rolloverBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rollover body: %w", err)
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	req := esapi.IndicesRolloverRequest{
+		Alias: aliasName,
+		Body:  body,
+	}
+	if dryRun {
+		req.DryRun = &dryRun
+	}
+
+	res, err := req.Do(ctx, s.client.client)
+	if err != nil {
+		return fmt.Errorf("failed to rollover index: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			s.client.config.Logger.Warn("Failed to close response body - error: %s",
+				err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("failed to rollover alias '%s': %s - %s", aliasName, res.Status(), string(bodyBytes))
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode rollover response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package elastic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRolloverConditionsToMap(t *testing.T) {
+	conditions := RolloverConditions{
+		MaxAge:              7 * 24 * time.Hour,
+		MaxDocs:             1000000,
+		MaxPrimaryShardSize: "50gb",
+	}.toMap()
+
+	if conditions["max_age"] != "604800s" {
+		t.Errorf("Expected max_age '604800s', got %v", conditions["max_age"])
+	}
+	if conditions["max_docs"] != int64(1000000) || conditions["max_primary_shard_size"] != "50gb" {
+		t.Errorf("Unexpected conditions: %v", conditions)
+	}
+	if _, ok := conditions["max_size"]; ok {
+		t.Error("Expected unset max_size to be omitted")
+	}
+}
+
+func TestRolloverWithConditionsDryRun(t *testing.T) {
+	var requestQuery, requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestQuery, requestBody = r.URL.RawQuery, string(body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"acknowledged": false, "shards_acknowledged": false,
+			"old_index": "logs-000001", "new_index": "logs-000002",
+			"rolled_over": false, "dry_run": true,
+			"conditions": {"[max_docs: 1000]": true}
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	response, err := client.Indices().RolloverWithConditions(context.Background(), "logs", RolloverConditions{MaxDocs: 1000}, WithRolloverDryRun(true))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestQuery != "dry_run=true" {
+		t.Errorf("Expected dry_run=true, got %q", requestQuery)
+	}
+	if requestBody != `{"conditions":{"max_docs":1000}}` {
+		t.Errorf("Unexpected request body: %s", requestBody)
+	}
+	if !response.DryRun || response.RolledOver || response.NewIndex != "logs-000002" || !response.Conditions["[max_docs: 1000]"] {
+		t.Errorf("Unexpected rollover response: %+v", response)
+	}
+}
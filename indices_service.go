@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v9/esapi"
@@ -14,21 +17,30 @@ import (
 // IndicesService methods
 
 // Create creates a new index with optional mapping
-func (s *IndicesService) Create(ctx context.Context, indexName string, mapping map[string]any) error {
+func (s *IndicesService) Create(ctx context.Context, indexName string, mapping map[string]any, options ...CreateIndexOption) (*CreateIndexResponse, error) {
 	indexResource := &IndexResource{
 		client: s.client,
 		name:   indexName,
 	}
-	return indexResource.Create(ctx, mapping)
+	return indexResource.Create(ctx, mapping, options...)
 }
 
 // Delete deletes an index
-func (s *IndicesService) Delete(ctx context.Context, indexName string) error {
+func (s *IndicesService) Delete(ctx context.Context, indexName string, options ...IndicesOption) error {
 	indexResource := &IndexResource{
 		client: s.client,
 		name:   indexName,
 	}
-	return indexResource.Delete(ctx)
+	return indexResource.Delete(ctx, options...)
+}
+
+// DeleteIfExists deletes an index if it exists and reports whether anything was deleted
+func (s *IndicesService) DeleteIfExists(ctx context.Context, indexName string, options ...IndicesOption) (bool, error) {
+	indexResource := &IndexResource{
+		client: s.client,
+		name:   indexName,
+	}
+	return indexResource.DeleteIfExists(ctx, options...)
 }
 
 // Exists checks if an index exists
@@ -48,6 +60,16 @@ func (s *IndicesService) Get(indexName string) *IndexResource {
 	}
 }
 
+// Pattern returns an IndexPattern for operating on all indices matching a wildcard
+// expression (e.g. "logs-2023-*"); multiple patterns can be comma-separated
+func (s *IndicesService) Pattern(pattern string, options ...IndicesOption) *IndexPattern {
+	return &IndexPattern{
+		client:  s.client,
+		pattern: pattern,
+		options: buildIndicesOptions(options),
+	}
+}
+
 // List returns detailed information about all indices
 func (s *IndicesService) List(ctx context.Context) ([]IndexInfo, error) {
 	if ctx == nil {
@@ -90,6 +112,10 @@ func (s *IndicesService) List(ctx context.Context) ([]IndexInfo, error) {
 
 // Close closes an index (makes it unavailable for read/write but preserves data)
 func (s *IndicesService) Close(ctx context.Context, indexName string) error {
+	if err := s.client.checkWritable("close index"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -121,6 +147,10 @@ func (s *IndicesService) Close(ctx context.Context, indexName string) error {
 
 // Open opens a previously closed index
 func (s *IndicesService) Open(ctx context.Context, indexName string) error {
+	if err := s.client.checkWritable("open index"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -152,6 +182,11 @@ func (s *IndicesService) Open(ctx context.Context, indexName string) error {
 
 // Refresh forces a refresh of specified indices (or all if none specified)
 func (s *IndicesService) Refresh(ctx context.Context, indexNames ...string) error {
+	return s.refresh(ctx, indexNames, indicesOptions{})
+}
+
+// refresh forces a refresh of the given indices using the given resolution options
+func (s *IndicesService) refresh(ctx context.Context, indexNames []string, opts indicesOptions) error {
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -159,7 +194,10 @@ func (s *IndicesService) Refresh(ctx context.Context, indexNames ...string) erro
 	}
 
 	req := esapi.IndicesRefreshRequest{
-		Index: indexNames, // Empty slice means all indices
+		Index:             indexNames, // Empty slice means all indices
+		IgnoreUnavailable: opts.ignoreUnavailable,
+		AllowNoIndices:    opts.allowNoIndices,
+		ExpandWildcards:   opts.expandWildcards,
 	}
 
 	res, err := req.Do(ctx, s.client.client)
@@ -182,7 +220,12 @@ func (s *IndicesService) Refresh(ctx context.Context, indexNames ...string) erro
 }
 
 // Stats returns statistics for specified indices (or all if none specified)
-func (s *IndicesService) Stats(ctx context.Context, indexNames ...string) (map[string]any, error) {
+func (s *IndicesService) Stats(ctx context.Context, indexNames ...string) (*IndexStats, error) {
+	return s.stats(ctx, indexNames, indicesOptions{})
+}
+
+// stats returns statistics for the given indices using the given resolution options
+func (s *IndicesService) stats(ctx context.Context, indexNames []string, opts indicesOptions) (*IndexStats, error) {
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -190,7 +233,8 @@ func (s *IndicesService) Stats(ctx context.Context, indexNames ...string) (map[s
 	}
 
 	req := esapi.IndicesStatsRequest{
-		Index: indexNames, // Empty slice means all indices
+		Index:           indexNames, // Empty slice means all indices
+		ExpandWildcards: opts.expandWildcards,
 	}
 
 	res, err := req.Do(ctx, s.client.client)
@@ -209,16 +253,32 @@ func (s *IndicesService) Stats(ctx context.Context, indexNames ...string) (map[s
 		return nil, fmt.Errorf("failed to get indices stats: %s - %s", res.Status(), string(bodyBytes))
 	}
 
-	var result map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode stats response: %w", err)
+	responseBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats response: %w", err)
 	}
 
-	return result, nil
+	return decodeIndexStats(responseBytes)
+}
+
+// decodeIndexStats decodes an index stats response, keeping the raw map as a fallback
+func decodeIndexStats(data []byte) (*IndexStats, error) {
+	var stats IndexStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode stats response: %w", err)
+	}
+	if err := json.Unmarshal(data, &stats.Raw); err != nil {
+		return nil, fmt.Errorf("failed to decode stats response: %w", err)
+	}
+	return &stats, nil
 }
 
 // Clone creates a copy of an existing index
 func (s *IndicesService) Clone(ctx context.Context, sourceIndex, targetIndex string) error {
+	if err := s.client.checkWritable("clone index"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -280,32 +340,8 @@ func (s *IndicesService) Reindex(ctx context.Context, sourceIndex, targetIndex s
 		}
 	}
 
-	bodyBytes, err := json.Marshal(reindexBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal reindex body: %w", err)
-	}
-
-	req := esapi.ReindexRequest{
-		Body: bytes.NewReader(bodyBytes),
-	}
-
-	res, err := req.Do(ctx, s.client.client)
-	if err != nil {
-		return fmt.Errorf("failed to reindex: %w", err)
-	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			s.client.config.Logger.Warn("Failed to close response body - error: %s",
-				err.Error())
-		}
-	}()
-
-	if res.IsError() {
-		bodyBytes, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("failed to reindex from '%s' to '%s': %s - %s", sourceIndex, targetIndex, res.Status(), string(bodyBytes))
-	}
-
-	return nil
+	_, err := s.reindex(ctx, sourceIndex, targetIndex, reindexBody)
+	return err
 }
 
 // Aliases returns all index aliases
@@ -344,61 +380,15 @@ func (s *IndicesService) Aliases(ctx context.Context) (map[string]any, error) {
 
 // Alias creates or updates an alias pointing to one or more indices
 func (s *IndicesService) Alias(ctx context.Context, aliasName string, indexNames ...string) error {
-	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-	}
-
-	if len(indexNames) == 0 {
-		return fmt.Errorf("at least one index name must be provided")
-	}
-
-	// Build alias actions
-	actions := make([]map[string]any, 0, len(indexNames))
-	for _, indexName := range indexNames {
-		actions = append(actions, map[string]any{
-			"add": map[string]any{
-				"index": indexName,
-				"alias": aliasName,
-			},
-		})
-	}
-
-	aliasBody := map[string]any{
-		"actions": actions,
-	}
-
-	bodyBytes, err := json.Marshal(aliasBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal alias body: %w", err)
-	}
-
-	req := esapi.IndicesUpdateAliasesRequest{
-		Body: bytes.NewReader(bodyBytes),
-	}
-
-	res, err := req.Do(ctx, s.client.client)
-	if err != nil {
-		return fmt.Errorf("failed to update aliases: %w", err)
-	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			s.client.config.Logger.Warn("Failed to close response body - error: %s",
-				err.Error())
-		}
-	}()
-
-	if res.IsError() {
-		bodyBytes, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("failed to create alias '%s': %s - %s", aliasName, res.Status(), string(bodyBytes))
-	}
-
-	return nil
+	return s.AliasWithOptions(ctx, aliasName, AliasOptions{}, indexNames...)
 }
 
 // RemoveAlias removes an alias from one or more indices
 func (s *IndicesService) RemoveAlias(ctx context.Context, aliasName string, indexNames ...string) error {
+	if err := s.client.checkWritable("remove alias"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -501,6 +491,10 @@ func (s *IndicesService) Analyze(ctx context.Context, indexName, text, analyzer
 
 // Shrink reduces the number of shards in an index
 func (s *IndicesService) Shrink(ctx context.Context, sourceIndex, targetIndex string, targetShards int) error {
+	if err := s.client.checkWritable("shrink index"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Minute) // Longer timeout for shrink
@@ -545,6 +539,11 @@ func (s *IndicesService) Shrink(ctx context.Context, sourceIndex, targetIndex st
 
 // Flush forces a flush of specified indices (or all if none specified)
 func (s *IndicesService) Flush(ctx context.Context, indexNames ...string) error {
+	return s.flush(ctx, indexNames, indicesOptions{})
+}
+
+// flush forces a flush of the given indices using the given resolution options
+func (s *IndicesService) flush(ctx context.Context, indexNames []string, opts indicesOptions) error {
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 2*time.Minute) // Longer timeout for flush
@@ -552,7 +551,10 @@ func (s *IndicesService) Flush(ctx context.Context, indexNames ...string) error
 	}
 
 	req := esapi.IndicesFlushRequest{
-		Index: indexNames, // Empty slice means all indices
+		Index:             indexNames, // Empty slice means all indices
+		IgnoreUnavailable: opts.ignoreUnavailable,
+		AllowNoIndices:    opts.allowNoIndices,
+		ExpandWildcards:   opts.expandWildcards,
 	}
 
 	res, err := req.Do(ctx, s.client.client)
@@ -574,40 +576,29 @@ func (s *IndicesService) Flush(ctx context.Context, indexNames ...string) error
 	return nil
 }
 
-// Rollover creates a new index when conditions are met and updates alias
-func (s *IndicesService) Rollover(ctx context.Context, aliasName string, options ...map[string]any) (map[string]any, error) {
+// ForceMerge merges the segments of an index down to maxNumSegments (0 lets Elasticsearch decide).
+// Only force merge indices that no longer receive writes, e.g. after a bulk load.
+func (s *IndicesService) ForceMerge(ctx context.Context, indexName string, maxNumSegments int) error {
+	if err := s.client.checkWritable("force merge"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Minute) // Merging large indices takes a while
 		defer cancel()
 	}
 
-	rolloverBody := map[string]any{}
-
-	// Apply options (conditions like max_age, max_size, etc.)
-	if len(options) > 0 {
-		for key, value := range options[0] {
-			rolloverBody[key] = value
-		}
-	}
-
-	var body io.Reader
-	if len(rolloverBody) > 0 {
-		bodyBytes, err := json.Marshal(rolloverBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal rollover body: %w", err)
-		}
-		body = bytes.NewReader(bodyBytes)
+	req := esapi.IndicesForcemergeRequest{
+		Index: []string{indexName},
 	}
-
-	req := esapi.IndicesRolloverRequest{
-		Alias: aliasName,
-		Body:  body,
+	if maxNumSegments > 0 {
+		req.MaxNumSegments = &maxNumSegments
 	}
 
 	res, err := req.Do(ctx, s.client.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to rollover index: %w", err)
+		return fmt.Errorf("failed to force merge index: %w", err)
 	}
 	defer func() {
 		if err := res.Body.Close(); err != nil {
@@ -618,12 +609,33 @@ func (s *IndicesService) Rollover(ctx context.Context, aliasName string, options
 
 	if res.IsError() {
 		bodyBytes, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("failed to rollover alias '%s': %s - %s", aliasName, res.Status(), string(bodyBytes))
+		return fmt.Errorf("failed to force merge index '%s': %s - %s", indexName, res.Status(), string(bodyBytes))
+	}
+
+	s.client.config.Logger.Info("Index force merged successfully - index: %s, max_num_segments: %d", indexName, maxNumSegments)
+	return nil
+}
+
+// Rollover creates a new index when conditions are met and updates alias
+func (s *IndicesService) Rollover(ctx context.Context, aliasName string, options ...map[string]any) (map[string]any, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	rolloverBody := map[string]any{}
+
+	// Apply options (conditions like max_age, max_size, etc.)
+	if len(options) > 0 {
+		for key, value := range options[0] {
+			rolloverBody[key] = value
+		}
 	}
 
 	var result map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode rollover response: %w", err)
+	if err := s.rollover(ctx, aliasName, rolloverBody, false, &result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -662,3 +674,92 @@ func (s *IndicesService) ListTemplates(ctx context.Context) (map[string]any, err
 	}
 	return clusterResource.ListTemplates(ctx)
 }
+
+// EnsureFromTemplate creates an index whose settings and mappings come from a composable
+// index template, but only if the index does not exist yet. It returns true if the index was created.
+// The template must exist and one of its index_patterns must match indexName.
+func (s *IndicesService) EnsureFromTemplate(ctx context.Context, indexName, templateName string) (bool, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	exists, err := s.Exists(ctx, indexName)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if index exists: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	template, err := s.GetTemplate(ctx, templateName)
+	if err != nil {
+		return false, err
+	}
+
+	patterns := templateIndexPatterns(template, templateName)
+	if !matchesAnyIndexPattern(indexName, patterns) {
+		return false, fmt.Errorf("template '%s' does not apply to index '%s' (index_patterns: %v)", templateName, indexName, patterns)
+	}
+
+	// Create without a body so the matching template supplies settings and mappings
+	if _, err := s.Create(ctx, indexName, nil, WithSkipExistsCheck()); err != nil {
+		// Another process may have created the index in the meantime
+		if isIndexAlreadyExistsError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// templateIndexPatterns extracts the index_patterns of a named template from a get template response
+func templateIndexPatterns(response map[string]any, templateName string) []string {
+	templates, _ := response["index_templates"].([]any)
+
+	var patterns []string
+	for _, t := range templates {
+		entry, ok := t.(map[string]any)
+		if !ok || entry["name"] != templateName {
+			continue
+		}
+		indexTemplate, _ := entry["index_template"].(map[string]any)
+		switch p := indexTemplate["index_patterns"].(type) {
+		case []any:
+			for _, pattern := range p {
+				if str, ok := pattern.(string); ok {
+					patterns = append(patterns, str)
+				}
+			}
+		case string:
+			patterns = append(patterns, p)
+		}
+	}
+
+	return patterns
+}
+
+// matchesAnyIndexPattern reports whether an index name matches one of the given wildcard patterns
+func matchesAnyIndexPattern(indexName string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, indexName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isIndexAlreadyExistsError checks if an error reports that the index already exists
+func isIndexAlreadyExistsError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrIndexAlreadyExists) {
+		return true
+	}
+	errStr := err.Error()
+	return strings.Contains(errStr, "resource_already_exists_exception") ||
+		strings.Contains(errStr, "already exists")
+}
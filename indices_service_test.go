@@ -0,0 +1,267 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+)
+
+func TestTemplateIndexPatterns(t *testing.T) {
+	body := `{
+		"index_templates": [
+			{"name": "logs", "index_template": {"index_patterns": ["logs-*", "audit-*"]}},
+			{"name": "metrics", "index_template": {"index_patterns": ["metrics-*"]}}
+		]
+	}`
+
+	var response map[string]any
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("Failed to unmarshal template response: %v", err)
+	}
+
+	patterns := templateIndexPatterns(response, "logs")
+	if len(patterns) != 2 || patterns[0] != "logs-*" || patterns[1] != "audit-*" {
+		t.Errorf("Expected [logs-* audit-*], got %v", patterns)
+	}
+
+	if !matchesAnyIndexPattern("logs-2024.01.01", patterns) {
+		t.Error("Expected logs-2024.01.01 to match logs-*")
+	}
+	if matchesAnyIndexPattern("metrics-2024", patterns) {
+		t.Error("Expected metrics-2024 not to match the logs template")
+	}
+}
+
+func TestDecodeIndexStats(t *testing.T) {
+	data := []byte(`{
+		"_shards": {"total": 2, "successful": 1, "failed": 0},
+		"_all": {
+			"primaries": {"docs": {"count": 10, "deleted": 1}, "store": {"size_in_bytes": 2048}, "indexing": {"index_total": 100}},
+			"total": {"docs": {"count": 20, "deleted": 2}, "search": {"query_total": 50}}
+		},
+		"indices": {
+			"logs": {"uuid": "abc", "health": "green", "primaries": {"docs": {"count": 10}}, "total": {"docs": {"count": 20}}}
+		}
+	}`)
+
+	stats, err := decodeIndexStats(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Shards.Total != 2 {
+		t.Errorf("Expected 2 total shards, got %d", stats.Shards.Total)
+	}
+	if stats.All.Primaries.Docs.Count != 10 || stats.All.Total.Docs.Deleted != 2 {
+		t.Errorf("Unexpected _all docs stats: %+v", stats.All)
+	}
+	if stats.All.Primaries.Store.SizeInBytes != 2048 {
+		t.Errorf("Expected store size 2048, got %d", stats.All.Primaries.Store.SizeInBytes)
+	}
+	logs, ok := stats.Indices["logs"]
+	if !ok {
+		t.Fatal("Expected per-index stats for 'logs'")
+	}
+	if logs.UUID != "abc" || logs.Health != "green" || logs.Total.Docs.Count != 20 {
+		t.Errorf("Unexpected per-index stats: %+v", logs)
+	}
+	if _, ok := stats.Raw["_all"]; !ok {
+		t.Error("Expected raw response to be kept")
+	}
+
+	prev := IndexStatsSection{Indexing: IndexingStats{IndexTotal: 40}}
+	if rate := stats.All.Primaries.IndexingRate(prev, 2*time.Second); rate != 30 {
+		t.Errorf("Expected indexing rate 30, got %v", rate)
+	}
+	if rate := stats.All.Primaries.IndexingRate(prev, 0); rate != 0 {
+		t.Errorf("Expected zero rate for zero elapsed, got %v", rate)
+	}
+}
+
+func TestReindexOptions(t *testing.T) {
+	options := ReindexOptions{
+		Query:     query.Term("status", "active"),
+		OpType:    "create",
+		Conflicts: "proceed",
+		MaxDocs:   500,
+	}
+	if err := options.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	body := buildReindexBody("products-v1", "products-v2", options)
+
+	source := body["source"].(map[string]any)
+	if source["index"] != "products-v1" || source["query"] == nil {
+		t.Errorf("Unexpected source: %v", source)
+	}
+	dest := body["dest"].(map[string]any)
+	if dest["index"] != "products-v2" || dest["op_type"] != "create" {
+		t.Errorf("Unexpected dest: %v", dest)
+	}
+	if body["conflicts"] != "proceed" || body["max_docs"] != 500 {
+		t.Errorf("Expected conflicts and max_docs in the body, got %v", body)
+	}
+
+	plain := buildReindexBody("a", "b", ReindexOptions{})
+	if _, ok := plain["conflicts"]; ok {
+		t.Error("Expected no conflicts setting by default")
+	}
+	if _, ok := plain["dest"].(map[string]any)["op_type"]; ok {
+		t.Error("Expected no op_type by default")
+	}
+
+	invalid := []ReindexOptions{
+		{OpType: "upsert"},
+		{Conflicts: "ignore"},
+		{MaxDocs: -1},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Expected validation error for %+v", opts)
+		}
+	}
+}
+
+func TestBuildAliasAction(t *testing.T) {
+	action := buildAliasAction("events", "tenant-42", AliasOptions{
+		Filter:        query.Term("tenant_id", "42"),
+		SearchRouting: "42",
+		IndexRouting:  "42",
+	})
+
+	if action["index"] != "events" || action["alias"] != "tenant-42" {
+		t.Errorf("Unexpected alias target: %v", action)
+	}
+	filter, ok := action["filter"].(map[string]any)
+	if !ok || filter["term"] == nil {
+		t.Errorf("Expected a term filter, got %v", action["filter"])
+	}
+	if action["search_routing"] != "42" || action["index_routing"] != "42" {
+		t.Errorf("Expected search and index routing, got %v", action)
+	}
+	if _, ok := action["routing"]; ok {
+		t.Error("Expected no routing when only search/index routing are set")
+	}
+
+	plain := buildAliasAction("events", "all-events", AliasOptions{})
+	if len(plain) != 2 {
+		t.Errorf("Expected a plain alias action, got %v", plain)
+	}
+}
+
+func TestCreateIndexSkipExistsCheck(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/existing" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_already_exists_exception", "reason": "index [existing/abc] already exists"}, "status": 400}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged": true, "shards_acknowledged": true, "index": "logs"}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	response, err := client.Indices().Create(context.Background(), "logs", nil, WithSkipExistsCheck())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !response.Acknowledged {
+		t.Error("Expected the index creation to be acknowledged")
+	}
+	if len(requests) != 1 || requests[0] != "PUT /logs" {
+		t.Errorf("Expected a single PUT request without an exists check, got %v", requests)
+	}
+
+	_, err = client.Indices().Create(context.Background(), "existing", nil, WithSkipExistsCheck())
+	if !errors.Is(err, ErrIndexAlreadyExists) {
+		t.Errorf("Expected ErrIndexAlreadyExists, got %v", err)
+	}
+}
+
+func TestGetMappingsThroughAlias(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"logs-000001": {"mappings": {"properties": {"message": {"type": "text"}}}},
+			"logs-000002": {"mappings": {"properties": {"message": {"type": "text"}, "level": {"type": "keyword"}}}}
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	mappings, err := client.Indices().GetMappings(context.Background(), "logs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requestedPath != "/logs/_mapping" {
+		t.Errorf("Expected request to /logs/_mapping, got %s", requestedPath)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("Expected one entry per backing index, got %d: %v", len(mappings), mappings)
+	}
+
+	first, ok := mappings["logs-000001"]
+	if !ok {
+		t.Fatal("Expected mappings for logs-000001")
+	}
+	if len(first.Properties()) != 1 {
+		t.Errorf("Expected 1 property for logs-000001, got %v", first.Properties())
+	}
+
+	second, ok := mappings["logs-000002"]
+	if !ok {
+		t.Fatal("Expected mappings for logs-000002")
+	}
+	level, ok := second.Properties()["level"].(map[string]any)
+	if !ok || level["type"] != "keyword" {
+		t.Errorf("Expected level keyword field on logs-000002, got %v", second.Properties())
+	}
+}
+
+func TestDeleteIfExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index [missing]"}, "status": 404}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+	ctx := context.Background()
+
+	existed, err := client.Indices().DeleteIfExists(ctx, "logs")
+	if err != nil || !existed {
+		t.Errorf("Expected an existing index to be deleted, got %t (%v)", existed, err)
+	}
+
+	existed, err = client.Indices().DeleteIfExists(ctx, "missing")
+	if err != nil || existed {
+		t.Errorf("Expected a missing index to report false without error, got %t (%v)", existed, err)
+	}
+
+	if _, err := client.Indices().DeleteIfExists(ctx, "missing", WithIgnoreUnavailable(true)); err == nil {
+		t.Error("Expected WithIgnoreUnavailable to be rejected")
+	}
+}
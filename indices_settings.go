@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v9/esapi"
@@ -66,6 +68,10 @@ func (is *IndexSettings) Get(ctx context.Context) (map[string]any, error) {
 
 // Update updates the index settings
 func (is *IndexSettings) Update(ctx context.Context, settings map[string]any) error {
+	if err := is.client.checkWritable("update settings"); err != nil {
+		return err
+	}
+
 	if ctx == nil {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -131,3 +137,99 @@ func (is *IndexSettings) Refresh(ctx context.Context) error {
 
 	return nil
 }
+
+// GetNumberOfReplicas returns the configured number of replicas for the index
+func (is *IndexSettings) GetNumberOfReplicas(ctx context.Context) (int, error) {
+	value, err := is.getIndexSetting(ctx, "number_of_replicas")
+	if err != nil {
+		return 0, err
+	}
+
+	replicas, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number_of_replicas value '%s': %w", value, err)
+	}
+
+	return replicas, nil
+}
+
+// GetNumberOfShards returns the configured number of primary shards for the index
+func (is *IndexSettings) GetNumberOfShards(ctx context.Context) (int, error) {
+	value, err := is.getIndexSetting(ctx, "number_of_shards")
+	if err != nil {
+		return 0, err
+	}
+
+	shards, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number_of_shards value '%s': %w", value, err)
+	}
+
+	return shards, nil
+}
+
+// GetRefreshInterval returns the configured refresh interval (e.g. "1s", or "-1" when disabled)
+// An empty string means the index uses the cluster default
+func (is *IndexSettings) GetRefreshInterval(ctx context.Context) (string, error) {
+	value, err := is.getIndexSetting(ctx, "refresh_interval")
+	if err != nil && !errors.Is(err, errSettingNotFound) {
+		return "", err
+	}
+	return value, nil
+}
+
+// SetReplicas updates the number of replicas for the index
+func (is *IndexSettings) SetReplicas(ctx context.Context, replicas int) error {
+	if replicas < 0 {
+		return fmt.Errorf("number of replicas cannot be negative")
+	}
+
+	return is.Update(ctx, map[string]any{
+		"index": map[string]any{
+			"number_of_replicas": replicas,
+		},
+	})
+}
+
+// SetRefreshInterval updates the refresh interval for the index
+// A negative duration disables periodic refreshes (refresh_interval: -1)
+func (is *IndexSettings) SetRefreshInterval(ctx context.Context, interval time.Duration) error {
+	return is.Update(ctx, map[string]any{
+		"index": map[string]any{
+			"refresh_interval": formatTimeValue(interval),
+		},
+	})
+}
+
+// errSettingNotFound is returned when a setting is not explicitly set on the index
+var errSettingNotFound = errors.New("setting not found")
+
+// getIndexSetting reads a single value from the "index" settings section
+func (is *IndexSettings) getIndexSetting(ctx context.Context, key string) (string, error) {
+	settings, err := is.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if indexSettings, ok := settings["index"].(map[string]any); ok {
+		if value, exists := indexSettings[key]; exists {
+			return fmt.Sprint(value), nil
+		}
+	}
+
+	return "", fmt.Errorf("index setting '%s' for index '%s': %w", key, is.indexName, errSettingNotFound)
+}
+
+// formatTimeValue converts a duration to an Elasticsearch time unit string
+// Negative durations are mapped to "-1", which disables the related feature. Elasticsearch has no
+// unit below milliseconds, so a sub-millisecond remainder rounds up instead of being dropped.
+func formatTimeValue(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "-1"
+	case d%time.Second == 0:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	default:
+		return strconv.FormatInt(int64((d+time.Millisecond-1)/time.Millisecond), 10) + "ms"
+	}
+}
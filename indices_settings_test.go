@@ -0,0 +1,31 @@
+package elastic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimeValue(t *testing.T) {
+	tests := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{-1, "-1"},
+		{0, "0s"},
+		{time.Second, "1s"},
+		{30 * time.Second, "30s"},
+		{2 * time.Minute, "120s"},
+		{500 * time.Millisecond, "500ms"},
+		{1500 * time.Millisecond, "1500ms"},
+		{500 * time.Microsecond, "1ms"},
+		{1500*time.Millisecond + time.Nanosecond, "1501ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := formatTimeValue(tt.input); result != tt.expected {
+				t.Errorf("Expected formatTimeValue(%v) = %s, got %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
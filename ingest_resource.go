@@ -0,0 +1,97 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// IngestResource provides ingest pipeline operations
+type IngestResource struct {
+	client *Client
+}
+
+// SimulatePipelineOption configures SimulatePipeline
+type SimulatePipelineOption func(*simulatePipelineOptions)
+
+// simulatePipelineOptions holds the resolved simulate pipeline options
+type simulatePipelineOptions struct {
+	verbose bool
+}
+
+// WithSimulateVerbose returns the output of every processor for each document, so the step
+// where a transformation goes wrong can be pinpointed (see SimulatedDocument.ProcessorResults)
+func WithSimulateVerbose() SimulatePipelineOption {
+	return func(opts *simulatePipelineOptions) {
+		opts.verbose = true
+	}
+}
+
+// SimulatePipeline runs the given document sources through a pipeline definition using the _ingest/pipeline/_simulate API
+func (ir *IngestResource) SimulatePipeline(ctx context.Context, pipeline map[string]any, docs []map[string]any, options ...SimulatePipelineOption) (*SimulatePipelineResult, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("at least one document is required to simulate a pipeline")
+	}
+
+	var opts simulatePipelineOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	simulateDocs := make([]map[string]any, len(docs))
+	for i, doc := range docs {
+		simulateDocs[i] = map[string]any{"_source": doc}
+	}
+
+	bodyBytes, err := json.Marshal(map[string]any{
+		"pipeline": pipeline,
+		"docs":     simulateDocs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal simulate pipeline request: %w", err)
+	}
+
+	req := esapi.IngestSimulateRequest{
+		Body: bytes.NewReader(bodyBytes),
+	}
+	if opts.verbose {
+		req.Verbose = &opts.verbose
+	}
+
+	res, err := req.Do(ctx, ir.client.client)
+	if err != nil {
+		ir.client.config.Logger.Error("Failed to simulate pipeline - error: %s", err.Error())
+		return nil, fmt.Errorf("failed to simulate pipeline: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			ir.client.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		ir.client.config.Logger.Error("Failed to simulate pipeline - status: %s, response: %s", res.Status(), string(bodyBytes))
+		return nil, fmt.Errorf("simulate pipeline request failed: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var result SimulatePipelineResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode simulate pipeline response: %w", err)
+	}
+
+	ir.client.config.Logger.Debug("Pipeline simulated successfully - docs: %d, verbose: %t", len(result.Docs), opts.verbose)
+
+	return &result, nil
+}
@@ -0,0 +1,78 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSimulatePipelineVerbose(t *testing.T) {
+	var requestPath, verbose string
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		verbose = r.URL.Query().Get("verbose")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"docs": [
+			{"processor_results": [
+				{"processor_type": "lowercase", "status": "success", "doc": {"_index": "_index", "_id": "_id", "_source": {"name": "widget"}}},
+				{"processor_type": "set", "tag": "stamp", "status": "success", "doc": {"_index": "_index", "_id": "_id", "_source": {"name": "widget", "stage": "clean"}}}
+			]},
+			{"processor_results": [
+				{"processor_type": "lowercase", "status": "error", "error": {"type": "illegal_argument_exception", "reason": "field [name] not present as part of path [name]"}}
+			]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	pipeline := map[string]any{
+		"processors": []any{
+			map[string]any{"lowercase": map[string]any{"field": "name"}},
+			map[string]any{"set": map[string]any{"field": "stage", "value": "clean", "tag": "stamp"}},
+		},
+	}
+	docs := []map[string]any{{"name": "WIDGET"}, {"title": "no name"}}
+
+	result, err := client.Ingest().SimulatePipeline(context.Background(), pipeline, docs, WithSimulateVerbose())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestPath != "/_ingest/pipeline/_simulate" || verbose != "true" {
+		t.Errorf("Expected verbose simulate request, got %s verbose=%q", requestPath, verbose)
+	}
+	sentDocs, _ := body["docs"].([]any)
+	if len(sentDocs) != 2 || sentDocs[0].(map[string]any)["_source"].(map[string]any)["name"] != "WIDGET" {
+		t.Errorf("Expected documents wrapped in _source, got %v", body["docs"])
+	}
+
+	if len(result.Docs) != 2 {
+		t.Fatalf("Expected 2 simulated documents, got %d", len(result.Docs))
+	}
+	first := result.Docs[0]
+	if first.Failed() || len(first.ProcessorResults) != 2 || first.ProcessorResults[1].Tag != "stamp" {
+		t.Errorf("Unexpected processor results: %+v", first.ProcessorResults)
+	}
+	if source := first.Source(); source["stage"] != "clean" {
+		t.Errorf("Expected the last processor output as source, got %v", source)
+	}
+
+	second := result.Docs[1]
+	if !second.Failed() || second.ProcessorResults[0].Error.Type != "illegal_argument_exception" {
+		t.Errorf("Expected the second document to fail, got %+v", second.ProcessorResults)
+	}
+	if second.Source() != nil {
+		t.Errorf("Expected no source for a failed document, got %v", second.Source())
+	}
+
+	if _, err := client.Ingest().SimulatePipeline(context.Background(), pipeline, nil); err == nil {
+		t.Error("Expected error when no documents are given")
+	}
+}
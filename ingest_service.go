@@ -0,0 +1,16 @@
+package elastic
+
+import (
+	"context"
+)
+
+// IngestService methods
+
+// SimulatePipeline runs sample documents through an ingest pipeline definition without creating
+// the pipeline or indexing anything, so transformations can be verified before rolling it out
+func (s *IngestService) SimulatePipeline(ctx context.Context, pipeline map[string]any, docs []map[string]any, options ...SimulatePipelineOption) (*SimulatePipelineResult, error) {
+	ingestResource := &IngestResource{
+		client: s.client,
+	}
+	return ingestResource.SimulatePipeline(ctx, pipeline, docs, options...)
+}
@@ -1,5 +1,7 @@
 package elastic
 
+import "encoding/json"
+
 // Logger defines the interface for pluggable logging within go-elastic.
 // This allows users to integrate their preferred logging solution.
 type Logger interface {
@@ -28,3 +30,27 @@ func (n *NopLogger) Error(msg string, fields ...any) {}
 
 // Debug implements Logger.Debug with no operation
 func (n *NopLogger) Debug(msg string, fields ...any) {}
+
+// Codec defines the interface for pluggable JSON serialization within go-elastic.
+// This allows users to plug in a faster JSON library (e.g. jsoniter or sonic) for
+// document encoding, bulk bodies and search response decoding.
+type Codec interface {
+	// Marshal returns the JSON encoding of v
+	Marshal(v any) ([]byte, error)
+	// Unmarshal parses the JSON-encoded data and stores the result in v
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is a Codec backed by encoding/json.
+// This is used as the default codec when WithCodec is not provided.
+type JSONCodec struct{}
+
+// Marshal implements Codec.Marshal using json.Marshal
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Codec.Unmarshal using json.Unmarshal
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
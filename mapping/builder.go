@@ -0,0 +1,163 @@
+// Package mapping provides a fluent, type-safe way to build Elasticsearch index mappings
+package mapping
+
+import (
+	"encoding/json"
+)
+
+// Builder represents a mapping builder that constructs Elasticsearch index mappings
+type Builder struct {
+	properties map[string]any
+	dynamic    any
+}
+
+// New creates a new, empty mapping builder
+func New() *Builder {
+	return &Builder{
+		properties: map[string]any{},
+	}
+}
+
+// AddField adds a field of the given type with optional extra parameters (e.g., analyzer, format)
+func (b *Builder) AddField(name string, fieldType string, params ...map[string]any) *Builder {
+	field := map[string]any{
+		"type": fieldType,
+	}
+
+	for _, p := range params {
+		for key, value := range p {
+			field[key] = value
+		}
+	}
+
+	b.properties[name] = field
+	return b
+}
+
+// AddText adds a full-text field
+func (b *Builder) AddText(name string) *Builder {
+	return b.AddField(name, "text")
+}
+
+// AddTextWithKeyword adds a full-text field with a "keyword" sub-field for sorting and aggregations
+func (b *Builder) AddTextWithKeyword(name string) *Builder {
+	return b.AddField(name, "text", map[string]any{
+		"fields": map[string]any{
+			"keyword": map[string]any{
+				"type":         "keyword",
+				"ignore_above": 256,
+			},
+		},
+	})
+}
+
+// AddKeyword adds an exact-value keyword field
+func (b *Builder) AddKeyword(name string) *Builder {
+	return b.AddField(name, "keyword")
+}
+
+// AddDate adds a date field, optionally with a custom format
+func (b *Builder) AddDate(name string, format ...string) *Builder {
+	if len(format) > 0 && format[0] != "" {
+		return b.AddField(name, "date", map[string]any{"format": format[0]})
+	}
+	return b.AddField(name, "date")
+}
+
+// AddBoolean adds a boolean field
+func (b *Builder) AddBoolean(name string) *Builder {
+	return b.AddField(name, "boolean")
+}
+
+// AddInteger adds an integer field
+func (b *Builder) AddInteger(name string) *Builder {
+	return b.AddField(name, "integer")
+}
+
+// AddLong adds a long field
+func (b *Builder) AddLong(name string) *Builder {
+	return b.AddField(name, "long")
+}
+
+// AddFloat adds a float field
+func (b *Builder) AddFloat(name string) *Builder {
+	return b.AddField(name, "float")
+}
+
+// AddDouble adds a double field
+func (b *Builder) AddDouble(name string) *Builder {
+	return b.AddField(name, "double")
+}
+
+// AddGeoPoint adds a geo_point field
+func (b *Builder) AddGeoPoint(name string) *Builder {
+	return b.AddField(name, "geo_point")
+}
+
+// AddDenseVector adds a dense_vector field with the given number of dimensions
+func (b *Builder) AddDenseVector(name string, dims int) *Builder {
+	return b.AddField(name, "dense_vector", map[string]any{"dims": dims})
+}
+
+// AddObject adds an object field whose properties are defined by a sub-mapping
+func (b *Builder) AddObject(name string, sub *Builder) *Builder {
+	b.properties[name] = map[string]any{
+		"type":       "object",
+		"properties": sub.copyProperties(),
+	}
+	return b
+}
+
+// AddNested adds a nested field whose properties are defined by a sub-mapping
+func (b *Builder) AddNested(name string, sub *Builder) *Builder {
+	b.properties[name] = map[string]any{
+		"type":       "nested",
+		"properties": sub.copyProperties(),
+	}
+	return b
+}
+
+// Dynamic sets the dynamic mapping behaviour (true, false, "strict" or "runtime")
+func (b *Builder) Dynamic(value any) *Builder {
+	b.dynamic = value
+	return b
+}
+
+// Mappings returns the mapping body ({"properties": {...}}) as used by the put mapping API
+func (b *Builder) Mappings() map[string]any {
+	mappings := map[string]any{
+		"properties": b.copyProperties(),
+	}
+	if b.dynamic != nil {
+		mappings["dynamic"] = b.dynamic
+	}
+	return mappings
+}
+
+// Build returns the complete index creation body ({"mappings": {"properties": {...}}})
+func (b *Builder) Build() map[string]any {
+	return map[string]any{
+		"mappings": b.Mappings(),
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (b *Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Build())
+}
+
+// String returns a JSON representation of the mapping
+func (b *Builder) String() string {
+	bytes, _ := json.MarshalIndent(b.Build(), "", "  ")
+	return string(bytes)
+}
+
+// copyProperties returns a shallow copy of the properties so later changes to
+// this builder don't leak into mappings that were already built from it
+func (b *Builder) copyProperties() map[string]any {
+	properties := make(map[string]any, len(b.properties))
+	for name, field := range b.properties {
+		properties[name] = field
+	}
+	return properties
+}
@@ -0,0 +1,76 @@
+package elastic
+
+import (
+	"testing"
+
+	"github.com/cloudresty/go-elastic/mapping"
+)
+
+func TestMappingBuilder(t *testing.T) {
+	items := mapping.New().
+		AddKeyword("sku").
+		AddInteger("quantity")
+
+	m := mapping.New().
+		AddText("title").
+		AddKeyword("status").
+		AddDate("created_at").
+		AddNested("items", items).
+		AddDenseVector("embedding", 384).
+		Build()
+
+	mappings, ok := m["mappings"].(map[string]any)
+	if !ok {
+		t.Fatal("Mapping should have 'mappings' field")
+	}
+
+	properties, ok := mappings["properties"].(map[string]any)
+	if !ok {
+		t.Fatal("Mappings should have 'properties' field")
+	}
+
+	expectedTypes := map[string]string{
+		"title":      "text",
+		"status":     "keyword",
+		"created_at": "date",
+		"items":      "nested",
+		"embedding":  "dense_vector",
+	}
+
+	for field, expectedType := range expectedTypes {
+		fieldMap, ok := properties[field].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected field '%s' to be present", field)
+		}
+		if fieldMap["type"] != expectedType {
+			t.Errorf("Expected field '%s' to have type '%s', got %v", field, expectedType, fieldMap["type"])
+		}
+	}
+
+	embedding := properties["embedding"].(map[string]any)
+	if embedding["dims"] != 384 {
+		t.Errorf("Expected embedding dims=384, got %v", embedding["dims"])
+	}
+
+	nestedProps, ok := properties["items"].(map[string]any)["properties"].(map[string]any)
+	if !ok {
+		t.Fatal("Nested field should have 'properties'")
+	}
+	if nestedProps["sku"].(map[string]any)["type"] != "keyword" {
+		t.Errorf("Expected nested sku to be keyword, got %v", nestedProps["sku"])
+	}
+}
+
+func TestMappingBuilderMappings(t *testing.T) {
+	m := mapping.New().AddKeyword("status").Dynamic("strict").Mappings()
+
+	if _, ok := m["mappings"]; ok {
+		t.Fatal("Mappings() should not wrap the body in 'mappings'")
+	}
+	if m["dynamic"] != "strict" {
+		t.Errorf("Expected dynamic=strict, got %v", m["dynamic"])
+	}
+	if _, ok := m["properties"].(map[string]any)["status"]; !ok {
+		t.Error("Expected status field in properties")
+	}
+}
@@ -2,7 +2,10 @@
 package query
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 )
 
 // Builder represents a query builder that constructs Elasticsearch queries
@@ -113,6 +116,41 @@ func (b *Builder) MinimumShouldMatch(count int) *Builder {
 	return b
 }
 
+// Clone returns a deep copy of the builder, so a shared base query can be
+// extended per request without mutating the original
+func (b *Builder) Clone() *Builder {
+	query, _ := deepCopy(b.query).(map[string]any)
+	return &Builder{query: query}
+}
+
+// deepCopy recursively copies maps and slices so no state is shared with the original
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	case []map[string]any:
+		copied := make([]map[string]any, len(v))
+		for i, item := range v {
+			copied[i], _ = deepCopy(item).(map[string]any)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	default:
+		return v
+	}
+}
+
 // Build returns the internal query map
 func (b *Builder) Build() map[string]any {
 	return b.query
@@ -123,10 +161,43 @@ func (b *Builder) MarshalJSON() ([]byte, error) {
 	return json.Marshal(b.query)
 }
 
-// String returns a JSON representation of the query
+// String returns an indented JSON representation of the query. Object keys are sorted, so
+// equal queries always produce the same string, e.g. for golden-file tests.
 func (b *Builder) String() string {
-	bytes, _ := json.MarshalIndent(b.query, "", "  ")
-	return string(bytes)
+	data, err := b.CanonicalJSON()
+	if err != nil {
+		return ""
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return indented.String()
+}
+
+// CanonicalJSON returns the compact JSON encoding of the query with object keys sorted at every
+// level. Array order, and therefore the order of bool clauses, is kept as added. Use it for cache keys.
+func (b *Builder) CanonicalJSON() ([]byte, error) {
+	return CanonicalJSON(b.query)
+}
+
+// CanonicalJSON returns the compact JSON encoding of v with object keys sorted at every level,
+// including values that implement json.Marshaler such as json.RawMessage. It always uses
+// encoding/json, so equal queries produce identical bytes. Array order is kept.
+func CanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("query: failed to marshal query: %w", err)
+	}
+
+	// Re-encode the generic form so map key sorting also applies inside custom marshalers
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("query: failed to canonicalize query: %w", err)
+	}
+	return json.Marshal(generic)
 }
 
 // Term creates a term query builder
@@ -269,6 +340,121 @@ func Fuzzy(field string, value string) *Builder {
 	}
 }
 
+// Raw wraps an arbitrary query clause (e.g. {"geo_shape": {...}}) so it can be used
+// anywhere a builder is accepted, such as inside bool clauses
+func Raw(clause map[string]any) *Builder {
+	query, _ := deepCopy(clause).(map[string]any)
+	if query == nil {
+		query = map[string]any{}
+	}
+	return &Builder{query: query}
+}
+
+// RawJSON wraps an arbitrary query clause given as JSON, e.g. `{"match_bool_prefix": {"title": "quick br"}}`
+func RawJSON(clause string) (*Builder, error) {
+	var query map[string]any
+	if err := json.Unmarshal([]byte(clause), &query); err != nil {
+		return nil, fmt.Errorf("query: invalid raw JSON clause: %w", err)
+	}
+	if len(query) == 0 {
+		return nil, errors.New("query: raw JSON clause must be a non-empty object")
+	}
+	return &Builder{query: query}, nil
+}
+
+// Pinned creates a pinned query builder that promotes the given document IDs, in order,
+// above the results of the organic query (match_all when organic is nil)
+func Pinned(ids []string, organic *Builder) *Builder {
+	if organic == nil {
+		organic = MatchAll()
+	}
+	return &Builder{
+		query: map[string]any{
+			"pinned": map[string]any{
+				"ids":     append([]string(nil), ids...),
+				"organic": organic.Build(),
+			},
+		},
+	}
+}
+
+// DistanceFeature creates a distance_feature query builder that boosts documents closer to origin,
+// e.g. DistanceFeature("published_at", "now", "7d") or DistanceFeature("location", []float64{-71.3, 41.15}, "1km")
+func DistanceFeature(field string, origin any, pivot string) *Builder {
+	return &Builder{
+		query: map[string]any{
+			"distance_feature": map[string]any{
+				"field":  field,
+				"origin": origin,
+				"pivot":  pivot,
+			},
+		},
+	}
+}
+
+// RankFeatureBuilder provides a fluent interface for building rank_feature queries
+type RankFeatureBuilder struct {
+	field    string
+	function string
+	params   map[string]any
+	boost    *float64
+}
+
+// RankFeature creates a new rank_feature query builder for a rank_feature or rank_features field
+func RankFeature(field string) *RankFeatureBuilder {
+	return &RankFeatureBuilder{
+		field: field,
+	}
+}
+
+// Saturation scores with S / (S + pivot); pivot <= 0 lets Elasticsearch pick a default
+func (r *RankFeatureBuilder) Saturation(pivot float64) *RankFeatureBuilder {
+	r.function = "saturation"
+	r.params = map[string]any{}
+	if pivot > 0 {
+		r.params["pivot"] = pivot
+	}
+	return r
+}
+
+// Log scores with log(scalingFactor + S)
+func (r *RankFeatureBuilder) Log(scalingFactor float64) *RankFeatureBuilder {
+	r.function = "log"
+	r.params = map[string]any{"scaling_factor": scalingFactor}
+	return r
+}
+
+// Sigmoid scores with S^exp / (S^exp + pivot^exp)
+func (r *RankFeatureBuilder) Sigmoid(pivot, exponent float64) *RankFeatureBuilder {
+	r.function = "sigmoid"
+	r.params = map[string]any{"pivot": pivot, "exponent": exponent}
+	return r
+}
+
+// Boost sets the boost applied to the feature score
+func (r *RankFeatureBuilder) Boost(boost float64) *RankFeatureBuilder {
+	r.boost = &boost
+	return r
+}
+
+// Build converts the rank_feature builder to a query builder
+func (r *RankFeatureBuilder) Build() *Builder {
+	rankFeature := map[string]any{
+		"field": r.field,
+	}
+	if r.function != "" {
+		rankFeature[r.function] = r.params
+	}
+	if r.boost != nil {
+		rankFeature["boost"] = *r.boost
+	}
+	return &Builder{
+		query: map[string]any{
+			"rank_feature": rankFeature,
+		},
+	}
+}
+
 // RangeBuilder provides a fluent interface for building range queries
 type RangeBuilder struct {
 	field string
@@ -330,6 +516,102 @@ func (r *RangeBuilder) Build() *Builder {
 	}
 }
 
+// IntervalsBuilder provides a fluent interface for building intervals queries,
+// which match terms by proximity and order
+type IntervalsBuilder struct {
+	field string
+	rule  map[string]any
+}
+
+// Intervals creates a new intervals query builder for the specified field
+func Intervals(field string) *IntervalsBuilder {
+	return &IntervalsBuilder{
+		field: field,
+		rule:  map[string]any{},
+	}
+}
+
+// Match sets the text whose analyzed terms must appear within the configured proximity
+func (i *IntervalsBuilder) Match(text string) *IntervalsBuilder {
+	i.rule["query"] = text
+	return i
+}
+
+// MaxGaps sets the maximum number of positions between matching terms (-1 for no limit)
+func (i *IntervalsBuilder) MaxGaps(gaps int) *IntervalsBuilder {
+	i.rule["max_gaps"] = gaps
+	return i
+}
+
+// Ordered sets whether the matching terms must appear in the order given
+func (i *IntervalsBuilder) Ordered(ordered bool) *IntervalsBuilder {
+	i.rule["ordered"] = ordered
+	return i
+}
+
+// Analyzer sets the analyzer used to analyze the match text
+func (i *IntervalsBuilder) Analyzer(analyzer string) *IntervalsBuilder {
+	i.rule["analyzer"] = analyzer
+	return i
+}
+
+// Build converts the intervals builder to a query builder
+func (i *IntervalsBuilder) Build() *Builder {
+	return &Builder{
+		query: map[string]any{
+			"intervals": map[string]any{
+				i.field: map[string]any{
+					"match": i.rule,
+				},
+			},
+		},
+	}
+}
+
+// SpanTerm creates a span_term query builder, the building block of span queries
+func SpanTerm(field string, value any) *Builder {
+	return &Builder{
+		query: map[string]any{
+			"span_term": map[string]any{
+				field: value,
+			},
+		},
+	}
+}
+
+// SpanNear creates a span_near query builder matching spans within slop positions of each other
+func SpanNear(slop int, inOrder bool, clauses ...*Builder) *Builder {
+	return &Builder{
+		query: map[string]any{
+			"span_near": map[string]any{
+				"clauses":  buildClauses(clauses),
+				"slop":     slop,
+				"in_order": inOrder,
+			},
+		},
+	}
+}
+
+// SpanOr creates a span_or query builder matching any of the given span clauses
+func SpanOr(clauses ...*Builder) *Builder {
+	return &Builder{
+		query: map[string]any{
+			"span_or": map[string]any{
+				"clauses": buildClauses(clauses),
+			},
+		},
+	}
+}
+
+// buildClauses converts builders to their query maps
+func buildClauses(queries []*Builder) []any {
+	clauses := make([]any, 0, len(queries))
+	for _, q := range queries {
+		clauses = append(clauses, q.Build())
+	}
+	return clauses
+}
+
 // Helper functions for Bool query clauses
 
 // Must creates a must clause
@@ -186,3 +186,197 @@ func TestPanicSafeMinimumShouldMatch(t *testing.T) {
 	// This should panic because Term() creates a non-bool query
 	query.Term("status", "active").MinimumShouldMatch(1)
 }
+
+func TestQueryBuilderClone(t *testing.T) {
+	base := query.New().Filter(query.Term("tenant", "acme"))
+
+	active := base.Clone().Must(query.Term("status", "active"))
+	archived := base.Clone().Must(query.Term("status", "archived"))
+
+	baseMust := base.Build()["bool"].(map[string]any)["must"].([]any)
+	if len(baseMust) != 0 {
+		t.Errorf("Expected base query to be unchanged, got must=%v", baseMust)
+	}
+
+	activeMust := active.Build()["bool"].(map[string]any)["must"].([]any)
+	archivedMust := archived.Build()["bool"].(map[string]any)["must"].([]any)
+	if len(activeMust) != 1 || len(archivedMust) != 1 {
+		t.Fatalf("Expected one must clause per clone, got %v and %v", activeMust, archivedMust)
+	}
+
+	activeTerm := activeMust[0].(map[string]any)["term"].(map[string]any)
+	if activeTerm["status"] != "active" {
+		t.Errorf("Expected active clone to filter on status=active, got %v", activeTerm)
+	}
+
+	// Mutating a clone's nested clause must not leak into the original
+	cloneFilter := active.Build()["bool"].(map[string]any)["filter"].([]any)
+	cloneFilter[0].(map[string]any)["term"].(map[string]any)["tenant"] = "other"
+	baseFilter := base.Build()["bool"].(map[string]any)["filter"].([]any)
+	if baseFilter[0].(map[string]any)["term"].(map[string]any)["tenant"] != "acme" {
+		t.Error("Expected Clone to deep copy nested clauses")
+	}
+}
+
+func TestRawQuery(t *testing.T) {
+	raw, err := query.RawJSON(`{"match_bool_prefix": {"title": "quick br"}}`)
+	if err != nil {
+		t.Fatalf("Failed to parse raw JSON: %v", err)
+	}
+
+	q := query.New().
+		Must(raw).
+		Filter(query.Raw(map[string]any{"geo_distance": map[string]any{"distance": "10km", "location": "40,-70"}})).
+		Build()
+
+	must := q["bool"].(map[string]any)["must"].([]any)
+	if _, ok := must[0].(map[string]any)["match_bool_prefix"]; !ok {
+		t.Errorf("Expected raw JSON clause in must, got %v", must)
+	}
+
+	filter := q["bool"].(map[string]any)["filter"].([]any)
+	if _, ok := filter[0].(map[string]any)["geo_distance"]; !ok {
+		t.Errorf("Expected raw map clause in filter, got %v", filter)
+	}
+
+	if _, err := query.RawJSON(`not json`); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if _, err := query.RawJSON(`{}`); err == nil {
+		t.Error("Expected error for empty clause")
+	}
+}
+
+func TestProximityQueries(t *testing.T) {
+	q := query.Intervals("body").Match("breach of contract").MaxGaps(3).Ordered(true).Build().Build()
+
+	match := q["intervals"].(map[string]any)["body"].(map[string]any)["match"].(map[string]any)
+	if match["query"] != "breach of contract" || match["max_gaps"] != 3 || match["ordered"] != true {
+		t.Errorf("Unexpected intervals match rule: %v", match)
+	}
+
+	span := query.SpanNear(2, false,
+		query.SpanTerm("body", "aspirin"),
+		query.SpanOr(query.SpanTerm("body", "headache"), query.SpanTerm("body", "migraine")),
+	).Build()
+
+	near := span["span_near"].(map[string]any)
+	if near["slop"] != 2 || near["in_order"] != false {
+		t.Errorf("Unexpected span_near params: %v", near)
+	}
+	clauses := near["clauses"].([]any)
+	if len(clauses) != 2 {
+		t.Fatalf("Expected 2 span_near clauses, got %d", len(clauses))
+	}
+	if _, ok := clauses[0].(map[string]any)["span_term"]; !ok {
+		t.Errorf("Expected span_term as first clause, got %v", clauses[0])
+	}
+	orClauses := clauses[1].(map[string]any)["span_or"].(map[string]any)["clauses"].([]any)
+	if len(orClauses) != 2 {
+		t.Errorf("Expected 2 span_or clauses, got %d", len(orClauses))
+	}
+}
+
+func TestPinnedQuery(t *testing.T) {
+	q := query.Pinned([]string{"promo-1", "promo-2"}, query.Match("title", "laptop")).Build()
+
+	pinned := q["pinned"].(map[string]any)
+	ids := pinned["ids"].([]string)
+	if len(ids) != 2 || ids[0] != "promo-1" {
+		t.Errorf("Expected pinned ids [promo-1 promo-2], got %v", ids)
+	}
+	if _, ok := pinned["organic"].(map[string]any)["match"]; !ok {
+		t.Errorf("Expected organic match query, got %v", pinned["organic"])
+	}
+
+	fallback := query.Pinned([]string{"promo-1"}, nil).Build()
+	if _, ok := fallback["pinned"].(map[string]any)["organic"].(map[string]any)["match_all"]; !ok {
+		t.Error("Expected nil organic query to default to match_all")
+	}
+}
+
+func TestFeatureQueries(t *testing.T) {
+	q := query.DistanceFeature("published_at", "now", "7d").Build()
+	df := q["distance_feature"].(map[string]any)
+	if df["field"] != "published_at" || df["origin"] != "now" || df["pivot"] != "7d" {
+		t.Errorf("Unexpected distance_feature query: %v", df)
+	}
+
+	rf := query.RankFeature("popularity").Saturation(8).Boost(2).Build().Build()["rank_feature"].(map[string]any)
+	if rf["field"] != "popularity" || rf["boost"] != 2.0 {
+		t.Errorf("Unexpected rank_feature query: %v", rf)
+	}
+	if rf["saturation"].(map[string]any)["pivot"] != 8.0 {
+		t.Errorf("Expected saturation pivot 8, got %v", rf["saturation"])
+	}
+
+	logged := query.RankFeature("pagerank").Saturation(0).Log(4).Build().Build()["rank_feature"].(map[string]any)
+	if _, ok := logged["saturation"]; ok {
+		t.Error("Expected Log to replace the saturation function")
+	}
+	if logged["log"].(map[string]any)["scaling_factor"] != 4.0 {
+		t.Errorf("Expected log scaling_factor 4, got %v", logged["log"])
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	build := func() *query.Builder {
+		return query.New().
+			Must(query.Match("title", "search"), query.Term("status", "published")).
+			Filter(query.Range("price").Gte(10).Lte(100).Build()).
+			Should(query.Raw(map[string]any{"terms": map[string]any{"tags": []string{"b", "a"}, "boost": 2}}))
+	}
+
+	first, err := build().CanonicalJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := build().CanonicalJSON()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("Expected stable output, got %s and %s", first, again)
+		}
+		if build().String() != build().String() {
+			t.Fatal("Expected String to be stable")
+		}
+	}
+
+	expected := `{"bool":{"filter":[{"range":{"price":{"gte":10,"lte":100}}}],"must":[{"match":{"title":"search"}},{"term":{"status":"published"}}],"must_not":[],"should":[{"terms":{"boost":2,"tags":["b","a"]}}]}}`
+	if string(first) != expected {
+		t.Errorf("Expected %s, got %s", expected, first)
+	}
+
+	rawValue, err := query.RawJSON(`{"term": {"id": 1}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body := map[string]any{"query": map[string]any{"bool": map[string]any{"must": []any{json.RawMessage(`{"z": 1, "a": {"y": 2, "b": 3}}`), rawValue}}}}
+	canonical, err := CanonicalJSON(body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := string(canonical); got != `{"query":{"bool":{"must":[{"a":{"b":3,"y":2},"z":1},{"term":{"id":1}}]}}}` {
+		t.Errorf("Expected keys inside raw messages to be sorted, got %s", got)
+	}
+}
+
+func TestFieldHelpersAreDeterministic(t *testing.T) {
+	fields := map[string]any{"status": "active", "category": "books", "author": "smith", "year": 2024}
+
+	q, err := CanonicalJSON(ByFields(fields))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"bool":{"filter":[],"must":[{"term":{"author":"smith"}},{"term":{"category":"books"}},{"term":{"status":"active"}},{"term":{"year":2024}}],"must_not":[],"should":[]}}`
+	if string(q) != expected {
+		t.Errorf("Expected %s, got %s", expected, q)
+	}
+
+	script := IncScript(map[string]any{"views": 1, "clicks": 2, "likes": 3})
+	if source := script["source"]; source != "ctx._source.clicks += params.clicks; ctx._source.likes += params.likes; ctx._source.views += params.views" {
+		t.Errorf("Expected statements ordered by field, got %v", source)
+	}
+}
@@ -1,5 +1,10 @@
 package elastic
 
+import (
+	"encoding/json"
+	"sort"
+)
+
 // Common Elasticsearch response types
 
 // IndexResponse represents the response from an index operation
@@ -25,6 +30,12 @@ type Hit struct {
 	ID     string         `json:"_id"`
 	Score  float64        `json:"_score"`
 	Source map[string]any `json:"_source"`
+	Sort   []any          `json:"sort,omitempty"`
+
+	// Fields holds the values requested with WithFields or WithDocValueFields, always as arrays
+	Fields map[string]any `json:"fields,omitempty"`
+
+	InnerHits map[string]any `json:"inner_hits,omitempty"`
 }
 
 // SearchResponse represents the response from a search operation
@@ -33,10 +44,11 @@ type SearchResponse struct {
 	TimedOut bool   `json:"timed_out"`
 	ScrollID string `json:"_scroll_id,omitempty"`
 	Shards   struct {
-		Total      int `json:"total"`
-		Successful int `json:"successful"`
-		Skipped    int `json:"skipped"`
-		Failed     int `json:"failed"`
+		Total      int            `json:"total"`
+		Successful int            `json:"successful"`
+		Skipped    int            `json:"skipped"`
+		Failed     int            `json:"failed"`
+		Failures   []ShardFailure `json:"failures,omitempty"`
 	} `json:"_shards"`
 	Hits struct {
 		Total struct {
@@ -47,6 +59,56 @@ type SearchResponse struct {
 		Hits     []Hit   `json:"hits"`
 	} `json:"hits"`
 	Aggregations map[string]any `json:"aggregations,omitempty"`
+
+	// TerminatedEarly is true when WithTerminateAfter stopped collection before all matches were seen
+	TerminatedEarly bool `json:"terminated_early,omitempty"`
+
+	// Clusters is set for cross-cluster searches and reports remote clusters that were skipped
+	Clusters *SearchClusters `json:"_clusters,omitempty"`
+}
+
+// SearchClusters represents the _clusters section of a cross-cluster search response
+type SearchClusters struct {
+	Total      int                             `json:"total"`
+	Successful int                             `json:"successful"`
+	Skipped    int                             `json:"skipped"`
+	Running    int                             `json:"running"`
+	Partial    int                             `json:"partial"`
+	Failed     int                             `json:"failed"`
+	Details    map[string]SearchClusterDetails `json:"details,omitempty"` // Keyed by cluster alias; "(local)" for the local cluster
+}
+
+// SearchClusterDetails represents the outcome of a cross-cluster search on a single cluster
+type SearchClusterDetails struct {
+	Status   string         `json:"status"` // "successful", "partial", "skipped", "failed" or "running"
+	Indices  string         `json:"indices"`
+	Took     int            `json:"took"`
+	TimedOut bool           `json:"timed_out"`
+	Failures []ShardFailure `json:"failures,omitempty"`
+}
+
+// SkippedClusters returns the aliases of the clusters that were skipped, e.g. because they were
+// unreachable and configured with skip_unavailable
+func (c *SearchClusters) SkippedClusters() []string {
+	if c == nil {
+		return nil
+	}
+
+	var skipped []string
+	for alias, details := range c.Details {
+		if details.Status == "skipped" {
+			skipped = append(skipped, alias)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// CreateIndexResponse represents the response from an index creation
+type CreateIndexResponse struct {
+	Acknowledged       bool   `json:"acknowledged"`
+	ShardsAcknowledged bool   `json:"shards_acknowledged"` // false if the shards didn't start before the timeout
+	Index              string `json:"index"`
 }
 
 // DeleteResponse represents the response from a delete operation
@@ -79,6 +141,15 @@ type UpdateResponse struct {
 	} `json:"_shards"`
 	SeqNo       int `json:"_seq_no"`
 	PrimaryTerm int `json:"_primary_term"`
+
+	// Get holds the updated document when requested with WithUpdateSource
+	Get *UpdateGetResult `json:"get,omitempty"`
+}
+
+// UpdateGetResult holds the document returned by an update request
+type UpdateGetResult struct {
+	Found  bool            `json:"found"`
+	Source json.RawMessage `json:"_source"`
 }
 
 // BulkResponse represents the response from a bulk operation
@@ -87,3 +158,19 @@ type BulkResponse struct {
 	Errors bool             `json:"errors"`
 	Items  []map[string]any `json:"items"`
 }
+
+// ShardFailure represents a failure of a single shard while executing a request
+type ShardFailure struct {
+	Shard  int        `json:"shard"`
+	Index  string     `json:"index"`
+	Node   string     `json:"node"`
+	Status string     `json:"status,omitempty"`
+	Reason ErrorCause `json:"reason"`
+}
+
+// ErrorCause represents an Elasticsearch error cause with its optional nested cause
+type ErrorCause struct {
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
+	CausedBy *ErrorCause `json:"caused_by,omitempty"`
+}
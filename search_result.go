@@ -16,6 +16,10 @@ type SearchResult[T any] struct {
 	Hits         TypedHits[T]   `json:"hits"`
 	Aggregations map[string]any `json:"aggregations,omitempty"`
 	Suggest      map[string]any `json:"suggest,omitempty"`
+
+	TerminatedEarly bool `json:"terminated_early,omitempty"`
+
+	Clusters *SearchClusters `json:"_clusters,omitempty"`
 }
 
 // TypedHits represents the hits section with typed documents
@@ -41,10 +45,11 @@ type TypedHit[T any] struct {
 
 // SearchShards represents shard information from a search response
 type SearchShards struct {
-	Total      int `json:"total"`
-	Successful int `json:"successful"`
-	Skipped    int `json:"skipped"`
-	Failed     int `json:"failed"`
+	Total      int            `json:"total"`
+	Successful int            `json:"successful"`
+	Skipped    int            `json:"skipped"`
+	Failed     int            `json:"failed"`
+	Failures   []ShardFailure `json:"failures,omitempty"`
 }
 
 // SearchTotal represents the total hits information
@@ -94,6 +99,51 @@ func (sr *SearchResult[T]) TotalHits() int {
 	return sr.Hits.Total.Value
 }
 
+// PageInfo describes a from/size page of a search result, for building paginated API responses
+type PageInfo struct {
+	Page       int  `json:"page"`        // 1-based number of the current page
+	Size       int  `json:"size"`        // Page size
+	From       int  `json:"from"`        // Offset of the current page
+	TotalHits  int  `json:"total_hits"`  // Total number of matching documents
+	TotalExact bool `json:"total_exact"` // False when TotalHits is a lower bound (track_total_hits limit)
+	TotalPages int  `json:"total_pages"` // Number of pages needed for TotalHits
+	HasNext    bool `json:"has_next"`    // More hits exist after the current page
+	NextFrom   int  `json:"next_from"`   // Offset of the next page, when HasNext
+
+	// BeyondResultWindow is set when the next page exists but from + size would exceed
+	// index.max_result_window, so Elasticsearch would reject it; switch to WithSearchAfter
+	// (with WithStableSort) or Scroll to keep paging
+	BeyondResultWindow bool `json:"beyond_result_window,omitempty"`
+}
+
+// Page computes pagination metadata for the page of the result that was requested with the given
+// from and size (a size <= 0 uses the Elasticsearch default of 10). A maxResultWindow <= 0 uses
+// DefaultMaxResultWindow.
+func (sr *SearchResult[T]) Page(from, size, maxResultWindow int) PageInfo {
+	if from < 0 {
+		from = 0
+	}
+	if size <= 0 {
+		size = defaultSearchSize
+	}
+
+	total := sr.TotalHits()
+	info := PageInfo{
+		Page:       from/size + 1,
+		Size:       size,
+		From:       from,
+		TotalHits:  total,
+		TotalExact: sr.Hits.Total.Relation != "gte",
+		TotalPages: (total + size - 1) / size,
+		HasNext:    from+size < total,
+	}
+	if info.HasNext {
+		info.NextFrom = from + size
+		info.BeyondResultWindow = CheckResultWindow(info.NextFrom, size, maxResultWindow) != nil
+	}
+	return info
+}
+
 // HasHits returns true if there are any hits
 func (sr *SearchResult[T]) HasHits() bool {
 	return len(sr.Hits.Hits) > 0
@@ -104,6 +154,26 @@ func (sr *SearchResult[T]) MaxScore() *float64 {
 	return sr.Hits.MaxScore
 }
 
+// ShardFailures returns the per-shard failures reported by the search, if any
+func (sr *SearchResult[T]) ShardFailures() []ShardFailure {
+	return sr.Shards.Failures
+}
+
+// PartialResults returns true if the hits may be incomplete because some shards failed, the
+// search timed out before all shards responded, or a cross-cluster search skipped or only
+// partially searched a remote cluster
+func (sr *SearchResult[T]) PartialResults() bool {
+	if sr.Clusters != nil && (sr.Clusters.Skipped > 0 || sr.Clusters.Partial > 0 || sr.Clusters.Failed > 0) {
+		return true
+	}
+	return sr.Shards.Failed > 0 || sr.TimedOut
+}
+
+// SkippedClusters returns the aliases of the remote clusters a cross-cluster search skipped
+func (sr *SearchResult[T]) SkippedClusters() []string {
+	return sr.Clusters.SkippedClusters()
+}
+
 // Each calls the provided function for each hit in the search result
 func (sr *SearchResult[T]) Each(fn func(hit TypedHit[T])) {
 	for _, hit := range sr.Hits.Hits {
@@ -131,6 +201,28 @@ func (sr *SearchResult[T]) Filter(fn func(T) bool) []T {
 	return filtered
 }
 
+// MapHits projects each hit, including its metadata (ID, index, score, sort values, ...),
+// into another type, e.g. a DTO returned by an API handler. Unlike SearchResult.Map,
+// which only sees the sources, fn receives the whole hit.
+func MapHits[T, U any](sr *SearchResult[T], fn func(hit TypedHit[T]) U) []U {
+	mapped := make([]U, len(sr.Hits.Hits))
+	for i, hit := range sr.Hits.Hits {
+		mapped[i] = fn(hit)
+	}
+	return mapped
+}
+
+// Reduce folds the documents of a search result into a single value, starting from initial,
+// e.g. to sum a price field over the returned page. Go methods cannot declare type parameters,
+// so unlike Map and Filter it is a package-level function.
+func Reduce[T, A any](sr *SearchResult[T], initial A, fn func(acc A, doc T) A) A {
+	acc := initial
+	for _, hit := range sr.Hits.Hits {
+		acc = fn(acc, hit.Source)
+	}
+	return acc
+}
+
 // First returns the first document if available
 func (sr *SearchResult[T]) First() (T, bool) {
 	var zero T
@@ -151,6 +243,11 @@ func (sr *SearchResult[T]) Last() (T, bool) {
 
 // ConvertSearchResponse converts a generic SearchResponse to a typed SearchResult[T]
 func ConvertSearchResponse[T any](response *SearchResponse) (*SearchResult[T], error) {
+	return convertSearchResponse[T](JSONCodec{}, response)
+}
+
+// convertSearchResponse converts a generic SearchResponse to a typed SearchResult[T] using the given codec
+func convertSearchResponse[T any](codec Codec, response *SearchResponse) (*SearchResult[T], error) {
 	typedResult := &SearchResult[T]{
 		Took:     response.Took,
 		TimedOut: response.TimedOut,
@@ -160,6 +257,7 @@ func ConvertSearchResponse[T any](response *SearchResponse) (*SearchResult[T], e
 			Successful: response.Shards.Successful,
 			Skipped:    response.Shards.Skipped,
 			Failed:     response.Shards.Failed,
+			Failures:   response.Shards.Failures,
 		},
 		Hits: TypedHits[T]{
 			Total: SearchTotal{
@@ -169,34 +267,121 @@ func ConvertSearchResponse[T any](response *SearchResponse) (*SearchResult[T], e
 			MaxScore: &response.Hits.MaxScore,
 			Hits:     make([]TypedHit[T], len(response.Hits.Hits)),
 		},
-		Aggregations: response.Aggregations,
+		Aggregations:    response.Aggregations,
+		TerminatedEarly: response.TerminatedEarly,
+		Clusters:        response.Clusters,
 	}
 
 	// Convert hits to typed hits
 	for i, hit := range response.Hits.Hits {
-		var doc T
-		if hit.Source != nil {
-			// Parse the source into the typed document
-			sourceBytes, err := json.Marshal(hit.Source)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal hit source: %w", err)
-			}
-
-			if err := json.Unmarshal(sourceBytes, &doc); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal hit source to type %T: %w", doc, err)
-			}
+		typedHit, err := convertHit[T](codec, hit)
+		if err != nil {
+			return nil, err
 		}
+		typedResult.Hits.Hits[i] = typedHit
+	}
 
-		typedResult.Hits.Hits[i] = TypedHit[T]{
-			Index:  hit.Index,
-			Type:   hit.Type,
-			ID:     hit.ID,
-			Score:  &hit.Score,
-			Source: doc,
+	return typedResult, nil
+}
+
+// convertHit converts a generic hit to a typed hit
+func convertHit[T any](codec Codec, hit Hit) (TypedHit[T], error) {
+	var doc T
+	if hit.Source != nil {
+		// Parse the source into the typed document
+		sourceBytes, err := codec.Marshal(hit.Source)
+		if err != nil {
+			return TypedHit[T]{}, fmt.Errorf("failed to marshal hit source: %w", err)
+		}
+
+		if err := codec.Unmarshal(sourceBytes, &doc); err != nil {
+			return TypedHit[T]{}, fmt.Errorf("failed to unmarshal hit source to type %T: %w", doc, err)
 		}
 	}
+	applyHitMetadata(&doc, hit)
+
+	return TypedHit[T]{
+		Index:     hit.Index,
+		Type:      hit.Type,
+		ID:        hit.ID,
+		Score:     &hit.Score,
+		Source:    doc,
+		Sort:      hit.Sort,
+		Fields:    hit.Fields,
+		InnerHits: hit.InnerHits,
+	}, nil
+}
 
-	return typedResult, nil
+// DecodeSource decodes the raw source of a hit returned by SearchRaw into U with codec (pass
+// client.Codec() so documents decode as they do in Search; nil uses JSONCodec), filling the hit
+// metadata fields of U (see Meta) like a typed search does. Decode into a small struct first to
+// read a discriminator field, then into the struct matching it:
+//
+//	kind, _ := elastic.DecodeSource[struct{ Type string `json:"type"` }](client.Codec(), hit)
+//	switch kind.Type {
+//	case "order":
+//		order, err := elastic.DecodeSource[Order](client.Codec(), hit)
+//	}
+func DecodeSource[U any](codec Codec, hit TypedHit[json.RawMessage]) (U, error) {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	var doc U
+	if len(hit.Source) > 0 {
+		if err := codec.Unmarshal(hit.Source, &doc); err != nil {
+			return doc, fmt.Errorf("failed to unmarshal hit source to type %T: %w", doc, err)
+		}
+	}
+
+	metadata := Hit{Index: hit.Index, ID: hit.ID, Sort: hit.Sort}
+	if hit.Score != nil {
+		metadata.Score = *hit.Score
+	}
+	applyHitMetadata(&doc, metadata)
+
+	return doc, nil
+}
+
+// FieldValues returns the values of a field requested with WithFields or WithDocValueFields.
+// Elasticsearch always returns field values as arrays, even for single-valued fields.
+func (h TypedHit[T]) FieldValues(name string) []any {
+	values, _ := h.Fields[name].([]any)
+	return values
+}
+
+// InnerHitsAs decodes the named inner_hits block of a hit (from a nested, has_child or
+// has_parent query, or field collapsing) into typed hits, e.g. InnerHitsAs[Variant](hit, "variants")
+func InnerHitsAs[U any, T any](hit TypedHit[T], name string) ([]TypedHit[U], error) {
+	block, ok := hit.InnerHits[name]
+	if !ok {
+		return nil, fmt.Errorf("inner hits '%s' not found on hit '%s'", name, hit.ID)
+	}
+
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inner hits '%s': %w", name, err)
+	}
+
+	var innerHits struct {
+		Hits struct {
+			Hits []Hit `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(blockBytes, &innerHits); err != nil {
+		return nil, fmt.Errorf("failed to decode inner hits '%s': %w", name, err)
+	}
+
+	typedHits := make([]TypedHit[U], len(innerHits.Hits.Hits))
+	for i, innerHit := range innerHits.Hits.Hits {
+		typedHit, err := convertHit[U](JSONCodec{}, innerHit)
+		if err != nil {
+			return nil, fmt.Errorf("inner hits '%s': %w", name, err)
+		}
+		typedHits[i] = typedHit
+	}
+
+	return typedHits, nil
 }
 
 // TypedSearchIterator provides a typed iterator pattern for scrolling through large result sets
@@ -210,6 +395,7 @@ type TypedSearchIterator[T any] struct {
 	err           error
 	totalHits     int64
 	processedHits int64
+	aggregations  map[string]any
 }
 
 // Next advances the iterator to the next document
@@ -219,6 +405,13 @@ func (tsi *TypedSearchIterator[T]) Next(ctx context.Context) bool {
 		return false
 	}
 
+	// Stop if the caller gave up, releasing the server-side scroll context
+	if ctx != nil && ctx.Err() != nil {
+		tsi.err = ctx.Err()
+		tsi.releaseScroll()
+		return false
+	}
+
 	// If we have more hits in the current batch, advance to next
 	if tsi.currentIndex < len(tsi.currentHits)-1 {
 		tsi.currentIndex++
@@ -235,12 +428,16 @@ func (tsi *TypedSearchIterator[T]) Next(ctx context.Context) bool {
 	// Need to fetch next batch
 	if err := tsi.fetchNextBatch(ctx); err != nil {
 		tsi.err = err
+		if ctx != nil && ctx.Err() != nil {
+			tsi.releaseScroll()
+		}
 		return false
 	}
 
 	// Check if we got new hits
 	if len(tsi.currentHits) == 0 {
 		tsi.done = true
+		tsi.releaseScroll()
 		return false
 	}
 
@@ -260,6 +457,38 @@ func (tsi *TypedSearchIterator[T]) Scan(dest *T) error {
 	return nil
 }
 
+// ScanInto decodes the current document into a destination of another type than T, such as a
+// projection struct. Tagged metadata fields (see Meta) on the destination are populated as well.
+func (tsi *TypedSearchIterator[T]) ScanInto(dest any) error {
+	if tsi.currentIndex < 0 || tsi.currentIndex >= len(tsi.currentHits) {
+		return fmt.Errorf("no current document - call Next() first")
+	}
+
+	currentHit := tsi.currentHits[tsi.currentIndex]
+
+	// Marshal the typed source back to JSON, then unmarshal to dest
+	sourceBytes, err := tsi.client.codec().Marshal(currentHit.Source)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document source: %w", err)
+	}
+
+	if err := tsi.client.codec().Unmarshal(sourceBytes, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal document into destination: %w", err)
+	}
+
+	hit := Hit{
+		Index: currentHit.Index,
+		ID:    currentHit.ID,
+		Sort:  currentHit.Sort,
+	}
+	if currentHit.Score != nil {
+		hit.Score = *currentHit.Score
+	}
+	applyHitMetadata(dest, hit)
+
+	return nil
+}
+
 // Current returns the current document
 func (tsi *TypedSearchIterator[T]) Current() T {
 	if tsi.currentIndex < 0 || tsi.currentIndex >= len(tsi.currentHits) {
@@ -292,7 +521,14 @@ func (tsi *TypedSearchIterator[T]) ProcessedHits() int64 {
 	return tsi.processedHits
 }
 
+// Aggregations returns the aggregations computed by the initial scroll search.
+// Elasticsearch only returns them with the first batch, so they stay available for the whole iteration.
+func (tsi *TypedSearchIterator[T]) Aggregations() map[string]any {
+	return tsi.aggregations
+}
+
 // Close cleans up the scroll context
+// It is safe to call Close multiple times, and after the iterator was exhausted or cancelled
 func (tsi *TypedSearchIterator[T]) Close(ctx context.Context) error {
 	if tsi.scrollID == "" {
 		return nil
@@ -311,6 +547,15 @@ func (tsi *TypedSearchIterator[T]) Close(ctx context.Context) error {
 	return nil
 }
 
+// releaseScroll clears the scroll context with a detached context, since the
+// caller's context may already be cancelled when iteration stops
+func (tsi *TypedSearchIterator[T]) releaseScroll() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = tsi.Close(ctx) // Failures are already logged by Close
+}
+
 // fetchNextBatch retrieves the next batch of results using the scroll API
 func (tsi *TypedSearchIterator[T]) fetchNextBatch(ctx context.Context) error {
 	if ctx == nil {
@@ -333,7 +578,7 @@ func (tsi *TypedSearchIterator[T]) fetchNextBatch(ctx context.Context) error {
 	tsi.scrollID = response.ScrollID
 
 	// Convert response to typed hits
-	typedResult, err := ConvertSearchResponse[T](response)
+	typedResult, err := convertSearchResponse[T](tsi.client.codec(), response)
 	if err != nil {
 		return fmt.Errorf("failed to convert scroll response: %w", err)
 	}
@@ -0,0 +1,407 @@
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateHistogramBucket represents a single bucket of a date_histogram aggregation
+type DateHistogramBucket struct {
+	Key         int64  // Bucket start as epoch milliseconds
+	KeyAsString string // Bucket start formatted by the aggregation's format
+	DocCount    int64
+
+	// SubAggregations holds the raw results of the bucket's sub-aggregations, keyed by name
+	SubAggregations map[string]any
+}
+
+// Time returns the bucket start as a UTC time
+func (b DateHistogramBucket) Time() time.Time {
+	return time.UnixMilli(b.Key).UTC()
+}
+
+// Value returns the value of a single-value metric sub-aggregation (avg, sum, max, cardinality, ...)
+func (b DateHistogramBucket) Value(name string) (float64, bool) {
+	return subAggregationValue(b.SubAggregations, name)
+}
+
+// DateHistogramAgg decodes the buckets of the named date_histogram aggregation
+func (sr *SearchResult[T]) DateHistogramAgg(name string) ([]DateHistogramBucket, error) {
+	return decodeDateHistogramBuckets(sr.Aggregations, name)
+}
+
+// decodeDateHistogramBuckets decodes date_histogram buckets from a raw aggregations map
+func decodeDateHistogramBuckets(aggregations map[string]any, name string) ([]DateHistogramBucket, error) {
+	rawBuckets, err := aggregationBuckets(aggregations, name, "date_histogram")
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]DateHistogramBucket, 0, len(rawBuckets))
+	for _, rawBucket := range rawBuckets {
+		bucket := DateHistogramBucket{
+			SubAggregations: map[string]any{},
+		}
+		for key, value := range rawBucket {
+			switch key {
+			case "key":
+				if number, ok := value.(float64); ok {
+					bucket.Key = int64(number)
+				}
+			case "key_as_string":
+				bucket.KeyAsString, _ = value.(string)
+			case "doc_count":
+				if number, ok := value.(float64); ok {
+					bucket.DocCount = int64(number)
+				}
+			default:
+				bucket.SubAggregations[key] = value
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// SubTerms decodes the named terms sub-aggregation
+func (b DateHistogramBucket) SubTerms(name string) (*TermsAggResult, error) {
+	return decodeTermsAggregation(b.SubAggregations, name)
+}
+
+// TermsAggResult represents the result of a terms aggregation. Terms are counted per shard and
+// merged, so counts can be approximate: tune size or shard_size when DocCountErrorUpperBound or
+// SumOtherDocCount matter to the caller.
+type TermsAggResult struct {
+	// DocCountErrorUpperBound is the maximum number of documents a term missing from the buckets
+	// could have; 0 means the returned counts are exact
+	DocCountErrorUpperBound int64
+
+	// SumOtherDocCount is the number of documents whose terms did not make it into the buckets
+	SumOtherDocCount int64
+
+	Buckets []TermsBucket
+}
+
+// Approximate reports whether the bucket counts may be inaccurate
+func (r *TermsAggResult) Approximate() bool {
+	return r.DocCountErrorUpperBound > 0
+}
+
+// TermsBucket represents a single bucket of a terms aggregation
+type TermsBucket struct {
+	Key         any    // Term as returned by Elasticsearch: a string, or a float64 for numeric fields
+	KeyAsString string // Formatted term, set for numeric, date and boolean fields
+	DocCount    int64
+
+	// DocCountErrorUpperBound is the worst-case error of DocCount, set with show_term_doc_count_error
+	DocCountErrorUpperBound int64
+
+	// SubAggregations holds the raw results of the bucket's sub-aggregations, keyed by name
+	SubAggregations map[string]any
+}
+
+// KeyString returns the term as a string, preferring key_as_string when Elasticsearch sent it
+func (b TermsBucket) KeyString() string {
+	if b.KeyAsString != "" {
+		return b.KeyAsString
+	}
+	if key, ok := b.Key.(string); ok {
+		return key
+	}
+	if b.Key == nil {
+		return ""
+	}
+	return fmt.Sprint(b.Key)
+}
+
+// Value returns the value of a single-value metric sub-aggregation (avg, sum, max, cardinality, ...)
+func (b TermsBucket) Value(name string) (float64, bool) {
+	return subAggregationValue(b.SubAggregations, name)
+}
+
+// SubTerms decodes the named terms sub-aggregation, e.g. the subcategories of a category bucket
+func (b TermsBucket) SubTerms(name string) (*TermsAggResult, error) {
+	return decodeTermsAggregation(b.SubAggregations, name)
+}
+
+// SubDateHistogram decodes the buckets of the named date_histogram sub-aggregation
+func (b TermsBucket) SubDateHistogram(name string) ([]DateHistogramBucket, error) {
+	return decodeDateHistogramBuckets(b.SubAggregations, name)
+}
+
+// SubAvg returns the value of the named avg sub-aggregation
+func (b TermsBucket) SubAvg(name string) (float64, bool) {
+	return b.Value(name)
+}
+
+// SubSum returns the value of the named sum sub-aggregation
+func (b TermsBucket) SubSum(name string) (float64, bool) {
+	return b.Value(name)
+}
+
+// SubMin returns the value of the named min sub-aggregation
+func (b TermsBucket) SubMin(name string) (float64, bool) {
+	return b.Value(name)
+}
+
+// SubMax returns the value of the named max sub-aggregation
+func (b TermsBucket) SubMax(name string) (float64, bool) {
+	return b.Value(name)
+}
+
+// SubCardinality returns the value of the named cardinality sub-aggregation
+func (b TermsBucket) SubCardinality(name string) (int64, bool) {
+	value, ok := b.Value(name)
+	return int64(value), ok
+}
+
+// TermsAgg decodes the named terms aggregation; use the buckets' Sub* methods to walk
+// nested aggregations, e.g. category → subcategory → average price
+func (sr *SearchResult[T]) TermsAgg(name string) (*TermsAggResult, error) {
+	return decodeTermsAggregation(sr.Aggregations, name)
+}
+
+// decodeTermsAggregation decodes a terms aggregation from a raw aggregations map
+func decodeTermsAggregation(aggregations map[string]any, name string) (*TermsAggResult, error) {
+	rawBuckets, err := aggregationBuckets(aggregations, name, "terms")
+	if err != nil {
+		return nil, err
+	}
+
+	agg := aggregations[name].(map[string]any)
+	result := &TermsAggResult{
+		DocCountErrorUpperBound: int64Value(agg["doc_count_error_upper_bound"]),
+		SumOtherDocCount:        int64Value(agg["sum_other_doc_count"]),
+	}
+
+	result.Buckets = make([]TermsBucket, 0, len(rawBuckets))
+	for _, rawBucket := range rawBuckets {
+		bucket := TermsBucket{
+			SubAggregations: map[string]any{},
+		}
+		for key, value := range rawBucket {
+			switch key {
+			case "key":
+				bucket.Key = value
+			case "key_as_string":
+				bucket.KeyAsString, _ = value.(string)
+			case "doc_count":
+				bucket.DocCount = int64Value(value)
+			case "doc_count_error_upper_bound":
+				bucket.DocCountErrorUpperBound = int64Value(value)
+			default:
+				bucket.SubAggregations[key] = value
+			}
+		}
+		result.Buckets = append(result.Buckets, bucket)
+	}
+
+	return result, nil
+}
+
+// int64Value converts a decoded JSON number to int64, returning 0 for anything else
+func int64Value(value any) int64 {
+	number, _ := value.(float64)
+	return int64(number)
+}
+
+// AdjacencyMatrixBucket represents a single bucket of an adjacency_matrix aggregation: either one
+// filter ("A") or the intersection of two filters ("A&B")
+type AdjacencyMatrixBucket struct {
+	Key      string
+	DocCount int64
+
+	// SubAggregations holds the raw results of the bucket's sub-aggregations, keyed by name
+	SubAggregations map[string]any
+}
+
+// Filters returns the names of the filters the bucket covers, split on the default "&" separator
+func (b AdjacencyMatrixBucket) Filters() []string {
+	return strings.Split(b.Key, adjacencyMatrixSeparator)
+}
+
+// IsIntersection reports whether the bucket counts documents matching two filters
+func (b AdjacencyMatrixBucket) IsIntersection() bool {
+	return strings.Contains(b.Key, adjacencyMatrixSeparator)
+}
+
+// Value returns the value of a single-value metric sub-aggregation (avg, sum, max, cardinality, ...)
+func (b AdjacencyMatrixBucket) Value(name string) (float64, bool) {
+	return subAggregationValue(b.SubAggregations, name)
+}
+
+// adjacencyMatrixSeparator joins filter names in the keys of intersection buckets
+const adjacencyMatrixSeparator = "&"
+
+// AdjacencyMatrixAgg decodes the buckets of the named adjacency_matrix aggregation. Elasticsearch
+// omits empty intersections, so a missing pair means the filters do not overlap.
+func (sr *SearchResult[T]) AdjacencyMatrixAgg(name string) ([]AdjacencyMatrixBucket, error) {
+	return decodeAdjacencyMatrixBuckets(sr.Aggregations, name)
+}
+
+// decodeAdjacencyMatrixBuckets decodes adjacency_matrix buckets from a raw aggregations map
+func decodeAdjacencyMatrixBuckets(aggregations map[string]any, name string) ([]AdjacencyMatrixBucket, error) {
+	rawBuckets, err := aggregationBuckets(aggregations, name, "adjacency_matrix")
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]AdjacencyMatrixBucket, 0, len(rawBuckets))
+	for _, rawBucket := range rawBuckets {
+		bucket := AdjacencyMatrixBucket{
+			SubAggregations: map[string]any{},
+		}
+		for key, value := range rawBucket {
+			switch key {
+			case "key":
+				bucket.Key, _ = value.(string)
+			case "doc_count":
+				if number, ok := value.(float64); ok {
+					bucket.DocCount = int64(number)
+				}
+			default:
+				bucket.SubAggregations[key] = value
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// MatrixStatsResult represents the result of a matrix_stats aggregation
+type MatrixStatsResult struct {
+	DocCount int64              `json:"doc_count"`
+	Fields   []MatrixStatsField `json:"fields"`
+}
+
+// MatrixStatsField holds the statistics of one field of a matrix_stats aggregation
+type MatrixStatsField struct {
+	Name        string             `json:"name"`
+	Count       int64              `json:"count"`
+	Mean        float64            `json:"mean"`
+	Variance    float64            `json:"variance"`
+	Skewness    float64            `json:"skewness"`
+	Kurtosis    float64            `json:"kurtosis"`
+	Covariance  map[string]float64 `json:"covariance"`  // Covariance with each field, keyed by field name
+	Correlation map[string]float64 `json:"correlation"` // Pearson correlation with each field, keyed by field name
+}
+
+// Field returns the statistics of the named field
+func (r MatrixStatsResult) Field(name string) (MatrixStatsField, bool) {
+	for _, field := range r.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return MatrixStatsField{}, false
+}
+
+// Correlation returns the correlation between two fields
+func (r MatrixStatsResult) Correlation(a, b string) (float64, bool) {
+	field, ok := r.Field(a)
+	if !ok {
+		return 0, false
+	}
+	value, ok := field.Correlation[b]
+	return value, ok
+}
+
+// Covariance returns the covariance between two fields
+func (r MatrixStatsResult) Covariance(a, b string) (float64, bool) {
+	field, ok := r.Field(a)
+	if !ok {
+		return 0, false
+	}
+	value, ok := field.Covariance[b]
+	return value, ok
+}
+
+// MatrixStatsAgg decodes the named matrix_stats aggregation
+func (sr *SearchResult[T]) MatrixStatsAgg(name string) (*MatrixStatsResult, error) {
+	var result MatrixStatsResult
+	if err := decodeAggregation(sr.Aggregations, name, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BoxplotResult represents the result of a boxplot aggregation. Lower and Upper are the whiskers:
+// the most extreme values within 1.5 times the interquartile range of Q1 and Q3.
+type BoxplotResult struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Q1    float64 `json:"q1"`
+	Q2    float64 `json:"q2"` // Median
+	Q3    float64 `json:"q3"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// IQR returns the interquartile range (Q3 - Q1)
+func (r BoxplotResult) IQR() float64 {
+	return r.Q3 - r.Q1
+}
+
+// BoxplotAgg decodes the named boxplot aggregation
+func (sr *SearchResult[T]) BoxplotAgg(name string) (*BoxplotResult, error) {
+	var result BoxplotResult
+	if err := decodeAggregation(sr.Aggregations, name, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// decodeAggregation decodes the raw result of the named aggregation into v
+func decodeAggregation(aggregations map[string]any, name string, v any) error {
+	agg, ok := aggregations[name].(map[string]any)
+	if !ok {
+		return fmt.Errorf("aggregation '%s' not found", name)
+	}
+
+	aggBytes, err := json.Marshal(agg)
+	if err != nil {
+		return fmt.Errorf("failed to encode aggregation '%s': %w", name, err)
+	}
+	if err := json.Unmarshal(aggBytes, v); err != nil {
+		return fmt.Errorf("failed to decode aggregation '%s': %w", name, err)
+	}
+	return nil
+}
+
+// aggregationBuckets returns the bucket objects of the named bucket aggregation
+func aggregationBuckets(aggregations map[string]any, name string, aggType string) ([]map[string]any, error) {
+	agg, ok := aggregations[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("aggregation '%s' not found", name)
+	}
+
+	rawBuckets, ok := agg["buckets"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("aggregation '%s' has no bucket list (is it a metric aggregation or a keyed %s?)", name, aggType)
+	}
+
+	buckets := make([]map[string]any, 0, len(rawBuckets))
+	for i, raw := range rawBuckets {
+		rawBucket, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("aggregation '%s' bucket %d is not an object", name, i)
+		}
+		buckets = append(buckets, rawBucket)
+	}
+
+	return buckets, nil
+}
+
+// subAggregationValue returns the value of a single-value metric sub-aggregation
+func subAggregationValue(subAggregations map[string]any, name string) (float64, bool) {
+	subAgg, ok := subAggregations[name].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	value, ok := subAgg["value"].(float64)
+	return value, ok
+}
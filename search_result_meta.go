@@ -0,0 +1,120 @@
+package elastic
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Meta can be embedded in a document struct to receive hit metadata during
+// ConvertSearchResponse, so a single typed slice carries the source together with
+// each hit's ID, index, score and sort values:
+//
+//	type Product struct {
+//		elastic.Meta
+//		Name string `json:"name"`
+//	}
+//
+// Fields of your own can opt in with the elastic tag instead: `elastic:"_id"` and
+// `elastic:"_index"` on a string, `elastic:"_score"` on a float64 or *float64, and
+// `elastic:"sort"` on a []any.
+type Meta struct {
+	ID    string  `json:"-" elastic:"_id"`
+	Index string  `json:"-" elastic:"_index"`
+	Score float64 `json:"-" elastic:"_score"`
+	Sort  []any   `json:"-" elastic:"sort"`
+}
+
+// hitMetadataField locates a tagged struct field that receives hit metadata
+type hitMetadataField struct {
+	index []int
+	key   string
+}
+
+// hitMetadataFieldCache caches the metadata fields found per document type
+var hitMetadataFieldCache sync.Map // map[reflect.Type][]hitMetadataField
+
+// hitMetadataFields returns the metadata fields of a struct type, including those of embedded structs
+func hitMetadataFields(structType reflect.Type) []hitMetadataField {
+	if cached, ok := hitMetadataFieldCache.Load(structType); ok {
+		return cached.([]hitMetadataField)
+	}
+
+	var fields []hitMetadataField
+	collectHitMetadataFields(structType, nil, &fields)
+
+	hitMetadataFieldCache.Store(structType, fields)
+	return fields
+}
+
+// collectHitMetadataFields walks a struct type and records fields with a supported elastic tag
+func collectHitMetadataFields(structType reflect.Type, parent []int, fields *[]hitMetadataField) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		index := append(append([]int{}, parent...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectHitMetadataFields(field.Type, index, fields)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("elastic")
+		if supportsHitMetadata(key, field.Type) {
+			*fields = append(*fields, hitMetadataField{index: index, key: key})
+		}
+	}
+}
+
+// supportsHitMetadata reports whether a field of the given type can receive the metadata key
+func supportsHitMetadata(key string, fieldType reflect.Type) bool {
+	switch key {
+	case "_id", "_index":
+		return fieldType.Kind() == reflect.String
+	case "_score":
+		return fieldType.Kind() == reflect.Float64 ||
+			(fieldType.Kind() == reflect.Pointer && fieldType.Elem().Kind() == reflect.Float64)
+	case "sort":
+		return fieldType == reflect.TypeOf([]any{})
+	default:
+		return false
+	}
+}
+
+// applyHitMetadata copies hit metadata into the tagged fields of the struct (or pointer to struct) doc points to
+func applyHitMetadata(doc any, hit Hit) {
+	value := reflect.ValueOf(doc)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return
+	}
+	value = value.Elem()
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, field := range hitMetadataFields(value.Type()) {
+		target := value.FieldByIndex(field.index)
+		switch field.key {
+		case "_id":
+			target.SetString(hit.ID)
+		case "_index":
+			target.SetString(hit.Index)
+		case "_score":
+			if target.Kind() == reflect.Pointer {
+				score := hit.Score
+				target.Set(reflect.ValueOf(&score).Convert(target.Type()))
+			} else {
+				target.SetFloat(hit.Score)
+			}
+		case "sort":
+			target.Set(reflect.ValueOf(hit.Sort))
+		}
+	}
+}
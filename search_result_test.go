@@ -0,0 +1,799 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudresty/go-elastic/query"
+)
+
+func TestConvertSearchResponseShardFailures(t *testing.T) {
+	raw := `{
+		"took": 5,
+		"timed_out": false,
+		"_shards": {
+			"total": 2,
+			"successful": 1,
+			"skipped": 0,
+			"failed": 1,
+			"failures": [{
+				"shard": 1,
+				"index": "products",
+				"node": "node-1",
+				"reason": {"type": "query_shard_exception", "reason": "failed to create query"}
+			}]
+		},
+		"hits": {"total": {"value": 1, "relation": "eq"}, "max_score": 1.0, "hits": [
+			{"_index": "products", "_id": "1", "_score": 1.0, "_source": {"name": "widget"}}
+		]}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	if !result.PartialResults() {
+		t.Error("Expected PartialResults() to be true when a shard failed")
+	}
+
+	failures := result.ShardFailures()
+	if len(failures) != 1 {
+		t.Fatalf("Expected 1 shard failure, got %d", len(failures))
+	}
+	if failures[0].Index != "products" || failures[0].Shard != 1 {
+		t.Errorf("Unexpected shard failure: %+v", failures[0])
+	}
+	if failures[0].Reason.Type != "query_shard_exception" {
+		t.Errorf("Expected reason type 'query_shard_exception', got '%s'", failures[0].Reason.Type)
+	}
+}
+
+func TestSearchResultPartialResults(t *testing.T) {
+	result := &SearchResult[map[string]any]{}
+	if result.PartialResults() {
+		t.Error("Expected PartialResults() to be false for a complete response")
+	}
+
+	result.TimedOut = true
+	if !result.PartialResults() {
+		t.Error("Expected PartialResults() to be true for a timed out response")
+	}
+}
+
+func TestTypedSearchIteratorStopsOnCancelledContext(t *testing.T) {
+	iterator := &TypedSearchIterator[map[string]any]{
+		currentHits:  []TypedHit[map[string]any]{{ID: "1"}, {ID: "2"}},
+		currentIndex: -1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if !iterator.Next(ctx) {
+		t.Fatal("Expected first Next() to succeed")
+	}
+
+	cancel()
+
+	if iterator.Next(ctx) {
+		t.Fatal("Expected Next() to stop after context cancellation")
+	}
+	if !errors.Is(iterator.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled error, got %v", iterator.Err())
+	}
+}
+
+func TestTypedSearchIteratorCloseIsIdempotent(t *testing.T) {
+	iterator := &TypedSearchIterator[map[string]any]{}
+
+	for i := 0; i < 2; i++ {
+		if err := iterator.Close(context.Background()); err != nil {
+			t.Errorf("Expected Close() call %d to succeed, got %v", i+1, err)
+		}
+	}
+}
+
+// scrollClearTransport records clear scroll requests and the deadline of the context they were sent with
+type scrollClearTransport struct {
+	paths     []string
+	deadlines []time.Duration
+}
+
+func (t *scrollClearTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/_search/scroll") {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		t.paths = append(t.paths, req.URL.Path)
+		if deadline, ok := req.Context().Deadline(); ok {
+			t.deadlines = append(t.deadlines, time.Until(deadline))
+		}
+	}
+
+	header := make(http.Header)
+	header.Set("X-Elastic-Product", "Elasticsearch")
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"succeeded": true, "num_freed": 1}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestTypedSearchIteratorClearsScrollOnceOnCancel(t *testing.T) {
+	transport := &scrollClearTransport{}
+	client, err := NewClient(WithConfig(&Config{
+		Hosts:        []string{"localhost:9200"},
+		LazyConnect:  true,
+		DisableRetry: true,
+		Transport:    transport,
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	iterator := &TypedSearchIterator[map[string]any]{
+		client:       client,
+		scrollID:     "scroll-abc",
+		scrollTime:   time.Minute,
+		currentHits:  []TypedHit[map[string]any]{{ID: "1"}, {ID: "2"}},
+		currentIndex: -1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if !iterator.Next(ctx) {
+		t.Fatal("Expected first Next() to succeed")
+	}
+	cancel()
+	if iterator.Next(ctx) {
+		t.Fatal("Expected Next() to stop after context cancellation")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := iterator.Close(context.Background()); err != nil {
+			t.Errorf("Expected Close() call %d to succeed, got %v", i+1, err)
+		}
+	}
+
+	if len(transport.paths) != 1 {
+		t.Fatalf("Expected exactly one clear scroll request, got %v", transport.paths)
+	}
+	if transport.paths[0] != "/_search/scroll/scroll-abc" {
+		t.Errorf("Expected the clear request to name the scroll ID, got %s", transport.paths[0])
+	}
+	if len(transport.deadlines) != 1 || transport.deadlines[0] <= 0 || transport.deadlines[0] > 5*time.Second {
+		t.Errorf("Expected the clear request to use a detached 5s context, got deadlines %v", transport.deadlines)
+	}
+}
+
+func TestDateHistogramAgg(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"per_day": {"buckets": [
+				{"key": 1704067200000, "key_as_string": "2024-01-01", "doc_count": 3, "revenue": {"value": 42.5}},
+				{"key": 1704153600000, "key_as_string": "2024-01-02", "doc_count": 0, "revenue": {"value": null}}
+			]},
+			"total": {"value": 10}
+		}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	buckets, err := result.DateHistogramAgg("per_day")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(buckets))
+	}
+	if buckets[0].Key != 1704067200000 || buckets[0].KeyAsString != "2024-01-01" || buckets[0].DocCount != 3 {
+		t.Errorf("Unexpected first bucket: %+v", buckets[0])
+	}
+	if got := buckets[0].Time().Format("2006-01-02"); got != "2024-01-01" {
+		t.Errorf("Expected bucket time 2024-01-01, got %s", got)
+	}
+	if value, ok := buckets[0].Value("revenue"); !ok || value != 42.5 {
+		t.Errorf("Expected revenue 42.5, got %v (%v)", value, ok)
+	}
+	if _, ok := buckets[1].Value("revenue"); ok {
+		t.Error("Expected null sub-aggregation value to be reported as missing")
+	}
+
+	if _, err := result.DateHistogramAgg("missing"); err == nil {
+		t.Error("Expected error for unknown aggregation")
+	}
+	if _, err := result.DateHistogramAgg("total"); err == nil {
+		t.Error("Expected error for non-bucket aggregation")
+	}
+}
+
+func TestExplainResponseReason(t *testing.T) {
+	raw := `{
+		"_index": "products",
+		"_id": "42",
+		"matched": false,
+		"explanation": {
+			"value": 0,
+			"description": "Failure to meet condition(s) of required/prohibited clause(s)",
+			"details": [
+				{"value": 0, "description": "no match on required clause (title:laptop)", "details": [
+					{"value": 0, "description": "no matching term", "details": []}
+				]},
+				{"value": 0, "description": "match on prohibited clause (status:discontinued)"}
+			]
+		}
+	}`
+
+	var response ExplainResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode explain response: %v", err)
+	}
+
+	if response.Matched {
+		t.Error("Expected matched=false")
+	}
+	expected := "no matching term; match on prohibited clause (status:discontinued)"
+	if reason := response.Reason(); reason != expected {
+		t.Errorf("Expected reason %q, got %q", expected, reason)
+	}
+
+	empty := ExplainResponse{Matched: true}
+	if reason := empty.Reason(); reason != "document matches the query" {
+		t.Errorf("Unexpected reason for empty explanation: %q", reason)
+	}
+}
+
+func TestConvertSearchResponsePopulatesID(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 2, "relation": "eq"}, "hits": [
+			{"_index": "products", "_id": "p-1", "_score": 1.0, "_source": {"name": "widget"}},
+			{"_index": "products", "_id": "p-2", "_score": 0.5, "_source": {"name": "gadget"}}
+		]}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+
+	type embedded struct {
+		Meta
+		Name string `json:"name"`
+	}
+	embeddedResult, err := ConvertSearchResponse[embedded](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+	docs := embeddedResult.Documents()
+	if docs[0].ID != "p-1" || docs[1].ID != "p-2" || docs[1].Name != "gadget" {
+		t.Errorf("Expected IDs on embedded Meta, got %+v", docs)
+	}
+
+	type tagged struct {
+		Key  string `json:"-" elastic:"_id"`
+		Name string `json:"name"`
+	}
+	taggedResult, err := ConvertSearchResponse[*tagged](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+	first, _ := taggedResult.First()
+	if first.Key != "p-1" || first.Name != "widget" {
+		t.Errorf("Expected tagged field to hold the ID, got %+v", first)
+	}
+}
+
+func TestConvertSearchResponsePopulatesHitMetadata(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+			{"_index": "products-2024", "_id": "p-1", "_score": 2.5, "_source": {"name": "widget"}, "sort": [2.5, "p-1"]}
+		]}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+
+	type embedded struct {
+		Meta
+		Name string `json:"name"`
+	}
+	embeddedResult, err := ConvertSearchResponse[embedded](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+	doc, _ := embeddedResult.First()
+	if doc.Index != "products-2024" || doc.Score != 2.5 || len(doc.Sort) != 2 || doc.Sort[1] != "p-1" {
+		t.Errorf("Unexpected embedded metadata: %+v", doc.Meta)
+	}
+	if hit := embeddedResult.Hits.Hits[0]; len(hit.Sort) != 2 {
+		t.Errorf("Expected sort values on the typed hit, got %v", hit.Sort)
+	}
+
+	type tagged struct {
+		Relevance *float64 `json:"-" elastic:"_score"`
+		Source    string   `json:"-" elastic:"_index"`
+		Ignored   int      `json:"-" elastic:"_score"`
+	}
+	taggedResult, err := ConvertSearchResponse[tagged](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+	tdoc, _ := taggedResult.First()
+	if tdoc.Relevance == nil || *tdoc.Relevance != 2.5 || tdoc.Source != "products-2024" {
+		t.Errorf("Unexpected tagged metadata: %+v", tdoc)
+	}
+	if tdoc.Ignored != 0 {
+		t.Errorf("Expected unsupported field type to be left untouched, got %d", tdoc.Ignored)
+	}
+}
+
+func TestTypedSearchIteratorAggregations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+				{"_index": "products", "_id": "1", "_source": {"name": "widget"}}
+			]},
+			"aggregations": {"brands": {"value": 3}}
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestServerClient(t, server)
+
+	iterator, err := For[map[string]any](client.Documents()).Scroll(context.Background(), query.MatchAll(), time.Minute, WithIndices("products"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	brands, ok := iterator.Aggregations()["brands"].(map[string]any)
+	if !ok || brands["value"] != float64(3) {
+		t.Errorf("Expected brands aggregation from the first batch, got %v", iterator.Aggregations())
+	}
+
+	count := 0
+	for iterator.Next(context.Background()) {
+		count++
+	}
+	if count != 1 || iterator.Err() != nil {
+		t.Errorf("Expected 1 hit without error, got %d (%v)", count, iterator.Err())
+	}
+	if iterator.Aggregations() == nil {
+		t.Error("Expected aggregations to remain available after iteration")
+	}
+}
+
+func TestInnerHitsAs(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+			{"_index": "catalog", "_id": "p-1", "_score": 1.0, "_source": {"name": "shirt"},
+			 "inner_hits": {"variants": {"hits": {"total": {"value": 2, "relation": "eq"}, "hits": [
+				{"_index": "catalog", "_id": "p-1", "_nested": {"field": "variants", "offset": 0}, "_score": 1.5, "_source": {"color": "red", "size": "M"}},
+				{"_index": "catalog", "_id": "p-1", "_nested": {"field": "variants", "offset": 2}, "_score": 1.2, "_source": {"color": "red", "size": "L"}}
+			 ]}}}}
+		]}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	type variant struct {
+		Color string `json:"color"`
+		Size  string `json:"size"`
+	}
+	variants, err := InnerHitsAs[variant](result.Hits.Hits[0], "variants")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(variants) != 2 {
+		t.Fatalf("Expected 2 inner hits, got %d", len(variants))
+	}
+	if variants[1].Source.Size != "L" || *variants[1].Score != 1.2 {
+		t.Errorf("Unexpected inner hit: %+v", variants[1])
+	}
+
+	if _, err := InnerHitsAs[variant](result.Hits.Hits[0], "missing"); err == nil {
+		t.Error("Expected an error for a missing inner hits block")
+	}
+}
+
+func TestTypedSearchIteratorScanInto(t *testing.T) {
+	score := 3.0
+	iterator := &TypedSearchIterator[map[string]any]{
+		currentHits: []TypedHit[map[string]any]{
+			{ID: "p-1", Index: "products", Score: &score, Source: map[string]any{"name": "widget", "price": 9.5, "stock": 4}},
+		},
+		currentIndex: -1,
+	}
+
+	type projection struct {
+		ID    string  `json:"-" elastic:"_id"`
+		Price float64 `json:"price"`
+	}
+
+	var dest projection
+	if err := iterator.ScanInto(&dest); err == nil {
+		t.Error("Expected an error before Next() is called")
+	}
+
+	if !iterator.Next(context.Background()) {
+		t.Fatal("Expected Next() to succeed")
+	}
+	if err := iterator.ScanInto(&dest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dest.Price != 9.5 || dest.ID != "p-1" {
+		t.Errorf("Unexpected projection: %+v", dest)
+	}
+}
+
+func TestTermsAggSubBuckets(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"categories": {"doc_count_error_upper_bound": 0, "sum_other_doc_count": 0, "buckets": [
+				{"key": "electronics", "doc_count": 5, "subcategories": {"buckets": [
+					{"key": "laptops", "doc_count": 3, "avg_price": {"value": 1200.5}},
+					{"key": "phones", "doc_count": 2, "avg_price": {"value": null}}
+				]}},
+				{"key": 2024, "key_as_string": "2024", "doc_count": 1, "subcategories": {"buckets": []}}
+			]}
+		}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	categoriesAgg, err := result.TermsAgg("categories")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	categories := categoriesAgg.Buckets
+	if len(categories) != 2 || categories[0].KeyString() != "electronics" || categories[0].DocCount != 5 {
+		t.Fatalf("Unexpected category buckets: %+v", categories)
+	}
+	if got := categories[1].KeyString(); got != "2024" {
+		t.Errorf("Expected numeric key as string 2024, got %q", got)
+	}
+
+	subcategoriesAgg, err := categories[0].SubTerms("subcategories")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	subcategories := subcategoriesAgg.Buckets
+	if len(subcategories) != 2 || subcategories[0].Key != "laptops" || subcategories[0].DocCount != 3 {
+		t.Fatalf("Unexpected subcategory buckets: %+v", subcategories)
+	}
+	if avg, ok := subcategories[0].SubAvg("avg_price"); !ok || avg != 1200.5 {
+		t.Errorf("Expected avg_price 1200.5, got %v (%v)", avg, ok)
+	}
+	if _, ok := subcategories[1].SubAvg("avg_price"); ok {
+		t.Error("Expected null sub-aggregation value to be reported as missing")
+	}
+
+	if _, err := categories[0].SubTerms("missing"); err == nil {
+		t.Error("Expected error for unknown sub-aggregation")
+	}
+	if _, err := subcategories[0].SubTerms("avg_price"); err == nil {
+		t.Error("Expected error for non-bucket sub-aggregation")
+	}
+}
+
+func TestAdjacencyMatrixAgg(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"segments": {"buckets": [
+				{"key": "segment_a", "doc_count": 10},
+				{"key": "segment_a&segment_b", "doc_count": 4, "avg_spend": {"value": 12.5}},
+				{"key": "segment_b", "doc_count": 7}
+			]}
+		}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	buckets, err := result.AdjacencyMatrixAgg("segments")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 buckets, got %d", len(buckets))
+	}
+	if buckets[0].IsIntersection() || buckets[0].DocCount != 10 {
+		t.Errorf("Unexpected single filter bucket: %+v", buckets[0])
+	}
+
+	intersection := buckets[1]
+	if !intersection.IsIntersection() || intersection.DocCount != 4 {
+		t.Errorf("Unexpected intersection bucket: %+v", intersection)
+	}
+	if filters := intersection.Filters(); len(filters) != 2 || filters[0] != "segment_a" || filters[1] != "segment_b" {
+		t.Errorf("Expected filters [segment_a segment_b], got %v", filters)
+	}
+	if value, ok := intersection.Value("avg_spend"); !ok || value != 12.5 {
+		t.Errorf("Expected avg_spend 12.5, got %v (%v)", value, ok)
+	}
+}
+
+func TestMatrixStatsAndBoxplotAgg(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"statistics": {"doc_count": 50, "fields": [
+				{"name": "income", "count": 50, "mean": 51985.1, "variance": 7.38e7, "skewness": 0.58, "kurtosis": 2.59,
+					"covariance": {"income": 7.38e7, "poverty": -21093.65},
+					"correlation": {"income": 1.0, "poverty": -0.85}},
+				{"name": "poverty", "count": 50, "mean": 12.73, "variance": 8.13, "skewness": 0.45, "kurtosis": 2.88,
+					"covariance": {"income": -21093.65, "poverty": 8.13},
+					"correlation": {"income": -0.85, "poverty": 1.0}}
+			]},
+			"load_time": {"min": 0.0, "max": 990.0, "q1": 167.5, "q2": 445.0, "q3": 722.5, "lower": 0.0, "upper": 990.0}
+		}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	stats, err := result.MatrixStatsAgg("statistics")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.DocCount != 50 || len(stats.Fields) != 2 {
+		t.Fatalf("Unexpected matrix stats: %+v", stats)
+	}
+	if correlation, ok := stats.Correlation("income", "poverty"); !ok || correlation != -0.85 {
+		t.Errorf("Expected income/poverty correlation -0.85, got %v (%v)", correlation, ok)
+	}
+	if field, ok := stats.Field("poverty"); !ok || field.Mean != 12.73 {
+		t.Errorf("Expected poverty mean 12.73, got %+v (%v)", field, ok)
+	}
+	if _, ok := stats.Covariance("income", "age"); ok {
+		t.Error("Expected missing covariance to be reported as missing")
+	}
+
+	boxplot, err := result.BoxplotAgg("load_time")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if boxplot.Q2 != 445 || boxplot.Max != 990 || boxplot.IQR() != 555 {
+		t.Errorf("Unexpected boxplot: %+v", boxplot)
+	}
+
+	if _, err := result.BoxplotAgg("missing"); err == nil {
+		t.Error("Expected error for unknown aggregation")
+	}
+}
+
+func TestSearchResultSkippedClusters(t *testing.T) {
+	raw := `{
+		"_shards": {"total": 10, "successful": 10, "skipped": 0, "failed": 0},
+		"_clusters": {"total": 3, "successful": 2, "skipped": 1, "running": 0, "partial": 0, "failed": 0, "details": {
+			"(local)": {"status": "successful", "indices": "logs", "took": 12, "timed_out": false},
+			"eu": {"status": "successful", "indices": "logs", "took": 40, "timed_out": false},
+			"us": {"status": "skipped", "indices": "logs", "timed_out": false, "failures": [
+				{"shard": -1, "index": null, "reason": {"type": "connect_transport_exception", "reason": "unable to connect"}}
+			]}
+		}},
+		"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [{"_index": "eu:logs", "_id": "1", "_source": {}}]}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	if result.Clusters == nil || result.Clusters.Total != 3 || result.Clusters.Skipped != 1 {
+		t.Fatalf("Expected 3 clusters with 1 skipped, got %+v", result.Clusters)
+	}
+	if skipped := result.SkippedClusters(); len(skipped) != 1 || skipped[0] != "us" {
+		t.Errorf("Expected skipped cluster us, got %v", skipped)
+	}
+	if failures := result.Clusters.Details["us"].Failures; len(failures) != 1 || failures[0].Reason.Type != "connect_transport_exception" {
+		t.Errorf("Expected a connect failure for us, got %+v", failures)
+	}
+	if !result.PartialResults() {
+		t.Error("Expected a skipped cluster to be reported as partial results")
+	}
+
+	local := &SearchResult[map[string]any]{}
+	if local.PartialResults() || local.SkippedClusters() != nil {
+		t.Error("Expected a local search to report no skipped clusters")
+	}
+}
+
+func TestTermsAggDocCountError(t *testing.T) {
+	raw := `{
+		"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"tags": {"doc_count_error_upper_bound": 46, "sum_other_doc_count": 1210, "buckets": [
+				{"key": "go", "doc_count": 400, "doc_count_error_upper_bound": 12},
+				{"key": "rust", "doc_count": 250, "doc_count_error_upper_bound": 0}
+			]},
+			"exact": {"doc_count_error_upper_bound": 0, "sum_other_doc_count": 0, "buckets": []}
+		}
+	}`
+
+	var response SearchResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		t.Fatalf("Failed to decode search response: %v", err)
+	}
+	result, err := ConvertSearchResponse[map[string]any](&response)
+	if err != nil {
+		t.Fatalf("Failed to convert search response: %v", err)
+	}
+
+	tags, err := result.TermsAgg("tags")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tags.DocCountErrorUpperBound != 46 || tags.SumOtherDocCount != 1210 || !tags.Approximate() {
+		t.Errorf("Expected approximate counts with error 46 and 1210 other docs, got %+v", tags)
+	}
+	if tags.Buckets[0].DocCountErrorUpperBound != 12 {
+		t.Errorf("Expected bucket error 12, got %d", tags.Buckets[0].DocCountErrorUpperBound)
+	}
+	if _, ok := tags.Buckets[0].SubAggregations["doc_count_error_upper_bound"]; ok {
+		t.Error("Expected the bucket error not to be reported as a sub-aggregation")
+	}
+
+	exact, err := result.TermsAgg("exact")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if exact.Approximate() || len(exact.Buckets) != 0 {
+		t.Errorf("Expected exact empty terms result, got %+v", exact)
+	}
+}
+
+func TestMapHits(t *testing.T) {
+	score := 1.5
+	result := &SearchResult[map[string]any]{
+		Hits: TypedHits[map[string]any]{
+			Hits: []TypedHit[map[string]any]{
+				{ID: "1", Score: &score, Source: map[string]any{"name": "widget"}},
+				{ID: "2", Source: map[string]any{"name": "gadget"}},
+			},
+		},
+	}
+
+	type productView struct {
+		ID    string
+		Name  string
+		Score float64
+	}
+	views := MapHits(result, func(hit TypedHit[map[string]any]) productView {
+		view := productView{ID: hit.ID, Name: hit.Source["name"].(string)}
+		if hit.Score != nil {
+			view.Score = *hit.Score
+		}
+		return view
+	})
+
+	if len(views) != 2 {
+		t.Fatalf("Expected 2 views, got %d", len(views))
+	}
+	if views[0] != (productView{ID: "1", Name: "widget", Score: 1.5}) || views[1].ID != "2" {
+		t.Errorf("Unexpected views: %+v", views)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	result := &SearchResult[map[string]any]{
+		Hits: TypedHits[map[string]any]{
+			Hits: []TypedHit[map[string]any]{
+				{Source: map[string]any{"price": 10.0}},
+				{Source: map[string]any{"price": 32.5}},
+			},
+		},
+	}
+
+	total := Reduce(result, 0.0, func(sum float64, doc map[string]any) float64 {
+		return sum + doc["price"].(float64)
+	})
+	if total != 42.5 {
+		t.Errorf("Expected total 42.5, got %v", total)
+	}
+
+	empty := &SearchResult[map[string]any]{}
+	if got := Reduce(empty, 7, func(acc int, _ map[string]any) int { return acc + 1 }); got != 7 {
+		t.Errorf("Expected initial value 7 for empty result, got %d", got)
+	}
+}
+
+func TestSearchResultPage(t *testing.T) {
+	result := &SearchResult[map[string]any]{
+		Hits: TypedHits[map[string]any]{Total: SearchTotal{Value: 45, Relation: "eq"}},
+	}
+
+	page := result.Page(20, 20, 0)
+	expected := PageInfo{Page: 2, Size: 20, From: 20, TotalHits: 45, TotalExact: true, TotalPages: 3, HasNext: true, NextFrom: 40}
+	if page != expected {
+		t.Errorf("Expected %+v, got %+v", expected, page)
+	}
+
+	last := result.Page(40, 20, 0)
+	if last.HasNext || last.NextFrom != 0 || last.Page != 3 {
+		t.Errorf("Expected last page without next, got %+v", last)
+	}
+
+	if defaults := result.Page(0, 0, 0); defaults.Size != 10 || defaults.TotalPages != 5 {
+		t.Errorf("Expected default size 10 and 5 pages, got %+v", defaults)
+	}
+}
+
+func TestSearchResultPageBeyondResultWindow(t *testing.T) {
+	result := &SearchResult[map[string]any]{
+		Hits: TypedHits[map[string]any]{Total: SearchTotal{Value: 10000, Relation: "gte"}},
+	}
+
+	page := result.Page(9900, 100, 0)
+	if page.TotalExact {
+		t.Error("Expected a gte total to be reported as inexact")
+	}
+	if page.HasNext {
+		t.Errorf("Expected no next page at the end of the lower bound total, got %+v", page)
+	}
+
+	page = result.Page(400, 100, 500)
+	if !page.HasNext || page.NextFrom != 500 || !page.BeyondResultWindow {
+		t.Errorf("Expected next page beyond a 500 result window, got %+v", page)
+	}
+	if page := result.Page(300, 100, 500); page.BeyondResultWindow {
+		t.Errorf("Expected next page within the result window, got %+v", page)
+	}
+}
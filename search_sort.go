@@ -0,0 +1,105 @@
+package elastic
+
+// SortBuilder provides a fluent interface for building sort clauses
+type SortBuilder struct {
+	field  string
+	params map[string]any
+}
+
+// NewSort creates a sort builder for the specified field (ascending by default)
+func NewSort(field string) *SortBuilder {
+	return &SortBuilder{
+		field: field,
+		params: map[string]any{
+			"order": "asc",
+		},
+	}
+}
+
+// Asc sorts in ascending order
+func (s *SortBuilder) Asc() *SortBuilder {
+	s.params["order"] = "asc"
+	return s
+}
+
+// Desc sorts in descending order
+func (s *SortBuilder) Desc() *SortBuilder {
+	s.params["order"] = "desc"
+	return s
+}
+
+// Mode sets how multi-valued fields are reduced to a single sort value ("min", "max", "sum", "avg" or "median")
+func (s *SortBuilder) Mode(mode string) *SortBuilder {
+	s.params["mode"] = mode
+	return s
+}
+
+// Missing sets where documents without the field are placed ("_last", "_first" or a custom value)
+func (s *SortBuilder) Missing(value any) *SortBuilder {
+	s.params["missing"] = value
+	return s
+}
+
+// UnmappedType sets the field type to assume in indices where the field is not mapped
+func (s *SortBuilder) UnmappedType(fieldType string) *SortBuilder {
+	s.params["unmapped_type"] = fieldType
+	return s
+}
+
+// NestedPath sorts by a field inside the given nested object path
+func (s *SortBuilder) NestedPath(path string) *SortBuilder {
+	s.nested()["path"] = path
+	return s
+}
+
+// NestedFilter restricts which nested objects are considered when sorting (requires NestedPath)
+func (s *SortBuilder) NestedFilter(filter map[string]any) *SortBuilder {
+	s.nested()["filter"] = filter
+	return s
+}
+
+// Build returns the sort clause, ready to be passed to WithSort
+func (s *SortBuilder) Build() map[string]any {
+	return map[string]any{
+		s.field: s.params,
+	}
+}
+
+// nested returns the nested sort options, creating them if needed
+func (s *SortBuilder) nested() map[string]any {
+	nested, ok := s.params["nested"].(map[string]any)
+	if !ok {
+		nested = map[string]any{}
+		s.params["nested"] = nested
+	}
+	return nested
+}
+
+// ShardDocTiebreaker is the WithStableSort tiebreaker for searches that use a point in time
+const ShardDocTiebreaker = "_shard_doc"
+
+// WithStableSort adds sort parameters like WithSort and, unless the resulting sort already includes
+// the tiebreaker field, appends it in ascending order so every hit has distinct sort values. Without
+// it, search_after pages skip or repeat documents that tie on every sort field.
+//
+// The tiebreaker must be unique per document: ShardDocTiebreaker when the search uses a point in
+// time, or otherwise a keyword field holding a unique value such as a copy of the document ID.
+// Elasticsearch 8.0+ rejects sorting on _id by default (indices.id_field_data.enabled is false),
+// so _id is not a usable tiebreaker. An empty tiebreaker adds no tiebreaker, as WithSort does.
+func WithStableSort(tiebreaker string, sorts ...map[string]any) SearchOption {
+	return func(query map[string]any) {
+		WithSort(sorts...)(query)
+		if tiebreaker == "" {
+			return
+		}
+
+		existing, _ := query["sort"].([]map[string]any)
+		for _, sort := range existing {
+			if _, ok := sort[tiebreaker]; ok {
+				return
+			}
+		}
+
+		query["sort"] = append(existing, SortAsc(tiebreaker))
+	}
+}
@@ -0,0 +1,73 @@
+package elastic
+
+import "testing"
+
+func TestSortBuilder(t *testing.T) {
+	sort := NewSort("offers.price").
+		Desc().
+		Mode("min").
+		Missing("_last").
+		UnmappedType("float").
+		NestedPath("offers").
+		NestedFilter(map[string]any{"term": map[string]any{"offers.active": true}}).
+		Build()
+
+	params, ok := sort["offers.price"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected sort on offers.price, got %v", sort)
+	}
+
+	expected := map[string]any{
+		"order":         "desc",
+		"mode":          "min",
+		"missing":       "_last",
+		"unmapped_type": "float",
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+
+	nested, ok := params["nested"].(map[string]any)
+	if !ok || nested["path"] != "offers" || nested["filter"] == nil {
+		t.Errorf("Expected nested path and filter, got %v", params["nested"])
+	}
+
+	body := map[string]any{}
+	WithSort(sort, NewSort("_score").Build())(body)
+	if sorts := body["sort"].([]map[string]any); len(sorts) != 2 {
+		t.Errorf("Expected 2 sort clauses, got %v", sorts)
+	}
+}
+
+func TestWithStableSort(t *testing.T) {
+	body := map[string]any{}
+	WithStableSort("order_id", SortDesc("created_at"))(body)
+	sorts := body["sort"].([]map[string]any)
+	if len(sorts) != 2 {
+		t.Fatalf("Expected the sort and a tiebreaker, got %v", sorts)
+	}
+	if _, ok := sorts[1]["order_id"]; !ok {
+		t.Errorf("Expected an order_id tiebreaker, got %v", sorts[1])
+	}
+
+	withPIT := map[string]any{"pit": map[string]any{"id": "abc", "keep_alive": "1m"}}
+	WithStableSort(ShardDocTiebreaker, SortDesc("created_at"))(withPIT)
+	if sorts := withPIT["sort"].([]map[string]any); len(sorts) != 2 || sorts[1]["_shard_doc"] == nil {
+		t.Errorf("Expected a _shard_doc tiebreaker with a point in time, got %v", sorts)
+	}
+
+	unique := map[string]any{}
+	WithSort(SortDesc("created_at"))(unique)
+	WithStableSort("order_id", SortAsc("order_id"))(unique)
+	if sorts := unique["sort"].([]map[string]any); len(sorts) != 2 {
+		t.Errorf("Expected no tiebreaker for a sort ending on the tiebreaker field, got %v", sorts)
+	}
+
+	untied := map[string]any{}
+	WithStableSort("", SortDesc("created_at"))(untied)
+	if sorts := untied["sort"].([]map[string]any); len(sorts) != 1 {
+		t.Errorf("Expected an empty tiebreaker to be ignored, got %v", sorts)
+	}
+}
@@ -0,0 +1,201 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// ServerInfo represents the information returned by the Elasticsearch root endpoint
+type ServerInfo struct {
+	Name        string        `json:"name"`
+	ClusterName string        `json:"cluster_name"`
+	ClusterUUID string        `json:"cluster_uuid"`
+	Version     ServerVersion `json:"version"`
+	Tagline     string        `json:"tagline"`
+}
+
+// ServerVersion represents the version details of an Elasticsearch server
+type ServerVersion struct {
+	Number                           string `json:"number"`
+	BuildFlavor                      string `json:"build_flavor"`
+	BuildType                        string `json:"build_type"`
+	BuildHash                        string `json:"build_hash"`
+	BuildDate                        string `json:"build_date"`
+	LuceneVersion                    string `json:"lucene_version"`
+	MinimumWireCompatibilityVersion  string `json:"minimum_wire_compatibility_version"`
+	MinimumIndexCompatibilityVersion string `json:"minimum_index_compatibility_version"`
+}
+
+// Major returns the major version number (e.g. 8 for "8.11.1"), or 0 if it cannot be parsed
+func (v ServerVersion) Major() int {
+	major, _, _ := parseVersion(v.Number)
+	return major
+}
+
+// Minor returns the minor version number (e.g. 11 for "8.11.1"), or 0 if it cannot be parsed
+func (v ServerVersion) Minor() int {
+	_, minor, _ := parseVersion(v.Number)
+	return minor
+}
+
+// ServerInfo returns the server information cached when the client connected
+func (c *Client) ServerInfo() (ServerInfo, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.serverInfo == nil {
+		return ServerInfo{}, fmt.Errorf("server info not available: client has not connected yet")
+	}
+
+	return *c.serverInfo, nil
+}
+
+// RefreshServerInfo fetches the server information again and updates the cached value
+// This is useful after a rolling upgrade of the cluster
+func (c *Client) RefreshServerInfo(ctx context.Context) (ServerInfo, error) {
+	if ctx == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+	}
+
+	client := c.GetClient()
+	if client == nil {
+		return ServerInfo{}, fmt.Errorf("client not connected")
+	}
+
+	req := esapi.InfoRequest{}
+
+	res, err := req.Do(ctx, client)
+	if err != nil {
+		return ServerInfo{}, fmt.Errorf("failed to get server info: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			c.config.Logger.Warn("Failed to close response body - error: %s", err.Error())
+		}
+	}()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return ServerInfo{}, fmt.Errorf("server info request failed: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	info, err := decodeServerInfo(res.Body)
+	if err != nil {
+		return ServerInfo{}, err
+	}
+
+	c.mutex.Lock()
+	c.serverInfo = info
+	c.mutex.Unlock()
+
+	return *info, nil
+}
+
+// decodeServerInfo decodes the body of an Info response
+func decodeServerInfo(body io.Reader) (*ServerInfo, error) {
+	var info ServerInfo
+	if err := json.NewDecoder(body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode server info response: %w", err)
+	}
+	return &info, nil
+}
+
+// parseVersion splits a version string like "8.11.1" or "9.0.0-SNAPSHOT" into its numeric parts
+func parseVersion(version string) (major, minor, patch int) {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.SplitN(version, ".", 3)
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		numbers[i] = n
+	}
+
+	return numbers[0], numbers[1], numbers[2]
+}
+
+// Feature identifies an Elasticsearch API that is only available on newer server versions
+type Feature string
+
+const (
+	// FeatureComposableTemplates covers the composable index template API (_index_template)
+	FeatureComposableTemplates Feature = "composable index templates"
+	// FeatureDataStreams covers the data stream APIs
+	FeatureDataStreams Feature = "data streams"
+	// FeaturePointInTime covers the point in time API
+	FeaturePointInTime Feature = "point in time"
+	// FeatureKNNSearch covers approximate kNN search
+	FeatureKNNSearch Feature = "kNN search"
+)
+
+// featureMinVersions lists the first Elasticsearch version that supports each feature
+var featureMinVersions = map[Feature]string{
+	FeatureComposableTemplates: "7.8.0",
+	FeatureDataStreams:         "7.9.0",
+	FeaturePointInTime:         "7.10.0",
+	FeatureKNNSearch:           "8.0.0",
+}
+
+// Supports reports whether the connected server supports the given feature.
+// If the server version is unknown the feature is assumed to be supported.
+func (c *Client) Supports(feature Feature) bool {
+	return c.requireFeature(feature) == nil
+}
+
+// requireFeature returns an UnsupportedFeatureError if the connected server is too old for the feature
+func (c *Client) requireFeature(feature Feature) error {
+	minVersion, ok := featureMinVersions[feature]
+	if !ok {
+		return nil
+	}
+
+	c.mutex.RLock()
+	info := c.serverInfo
+	c.mutex.RUnlock()
+
+	// Without a known version let the server decide
+	if info == nil || info.Version.Number == "" {
+		return nil
+	}
+
+	if compareVersions(info.Version.Number, minVersion) < 0 {
+		return &UnsupportedFeatureError{
+			Feature:       feature,
+			MinVersion:    minVersion,
+			ServerVersion: info.Version.Number,
+		}
+	}
+
+	return nil
+}
+
+// compareVersions returns -1, 0 or 1 depending on whether a is lower, equal or higher than b
+func compareVersions(a, b string) int {
+	aMajor, aMinor, aPatch := parseVersion(a)
+	bMajor, bMinor, bPatch := parseVersion(b)
+
+	for _, pair := range [][2]int{{aMajor, bMajor}, {aMinor, bMinor}, {aPatch, bPatch}} {
+		switch {
+		case pair[0] < pair[1]:
+			return -1
+		case pair[0] > pair[1]:
+			return 1
+		}
+	}
+
+	return 0
+}
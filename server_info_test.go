@@ -0,0 +1,80 @@
+package elastic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeServerInfo(t *testing.T) {
+	body := `{
+		"name": "node-1",
+		"cluster_name": "docker-cluster",
+		"cluster_uuid": "abc123",
+		"version": {"number": "8.11.1", "build_flavor": "default", "lucene_version": "9.8.0"},
+		"tagline": "You Know, for Search"
+	}`
+
+	info, err := decodeServerInfo(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to decode server info: %v", err)
+	}
+
+	if info.ClusterName != "docker-cluster" || info.ClusterUUID != "abc123" {
+		t.Errorf("Unexpected cluster details: %+v", info)
+	}
+	if info.Version.Major() != 8 || info.Version.Minor() != 11 {
+		t.Errorf("Expected version 8.11, got %d.%d", info.Version.Major(), info.Version.Minor())
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version             string
+		major, minor, patch int
+	}{
+		{"8.11.1", 8, 11, 1},
+		{"9.0.0-SNAPSHOT", 9, 0, 0},
+		{"7.17", 7, 17, 0},
+		{"", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, patch := parseVersion(tt.version)
+			if major != tt.major || minor != tt.minor || patch != tt.patch {
+				t.Errorf("Expected %d.%d.%d, got %d.%d.%d", tt.major, tt.minor, tt.patch, major, minor, patch)
+			}
+		})
+	}
+}
+
+func TestServerInfoNotConnected(t *testing.T) {
+	client := &Client{config: &Config{}}
+
+	if _, err := client.ServerInfo(); err == nil {
+		t.Error("Expected error when server info has not been fetched")
+	}
+}
+
+func TestRequireFeature(t *testing.T) {
+	client := &Client{config: &Config{}}
+
+	if err := client.requireFeature(FeaturePointInTime); err != nil {
+		t.Errorf("Expected unknown server version to be allowed, got %v", err)
+	}
+
+	client.serverInfo = &ServerInfo{Version: ServerVersion{Number: "7.9.3"}}
+
+	err := client.requireFeature(FeaturePointInTime)
+	if !errors.Is(err, ErrUnsupportedByServer) {
+		t.Fatalf("Expected ErrUnsupportedByServer, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "7.10.0") {
+		t.Errorf("Expected error to name the minimum version, got %q", err.Error())
+	}
+
+	if !client.Supports(FeatureDataStreams) {
+		t.Error("Expected data streams to be supported on 7.9.3")
+	}
+}
@@ -1,5 +1,7 @@
 package elastic
 
+import "time"
+
 // IndexInfo represents information about an Elasticsearch index
 type IndexInfo struct {
 	Index     string `json:"index"`
@@ -96,3 +98,216 @@ type IndexHealth struct {
 	InitializingShards  int    `json:"initializing_shards"`
 	UnassignedShards    int    `json:"unassigned_shards"`
 }
+
+// AllocationExplanation represents the response of the cluster allocation explain API
+type AllocationExplanation struct {
+	Index                   string                   `json:"index"`
+	Shard                   int                      `json:"shard"`
+	Primary                 bool                     `json:"primary"`
+	CurrentState            string                   `json:"current_state"`
+	CurrentNode             *AllocationNode          `json:"current_node,omitempty"`
+	UnassignedInfo          *UnassignedInfo          `json:"unassigned_info,omitempty"`
+	CanAllocate             string                   `json:"can_allocate,omitempty"`
+	AllocateExplanation     string                   `json:"allocate_explanation,omitempty"`
+	CanRemainOnCurrentNode  string                   `json:"can_remain_on_current_node,omitempty"`
+	CanRebalanceCluster     string                   `json:"can_rebalance_cluster,omitempty"`
+	CanRebalanceToOtherNode string                   `json:"can_rebalance_to_other_node,omitempty"`
+	RebalanceExplanation    string                   `json:"rebalance_explanation,omitempty"`
+	NodeAllocationDecisions []NodeAllocationDecision `json:"node_allocation_decisions,omitempty"`
+
+	// Raw holds the full response for fields not covered above
+	Raw map[string]any `json:"-"`
+}
+
+// AllocationNode identifies the node a shard is currently allocated to
+type AllocationNode struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	TransportAddress string `json:"transport_address"`
+}
+
+// UnassignedInfo describes why a shard is unassigned
+type UnassignedInfo struct {
+	Reason               string `json:"reason"`
+	At                   string `json:"at"`
+	LastAllocationStatus string `json:"last_allocation_status"`
+	Details              string `json:"details,omitempty"`
+}
+
+// NodeAllocationDecision represents the allocation decision for a shard on a single node
+type NodeAllocationDecision struct {
+	NodeID           string              `json:"node_id"`
+	NodeName         string              `json:"node_name"`
+	TransportAddress string              `json:"transport_address"`
+	NodeDecision     string              `json:"node_decision"`
+	WeightRanking    int                 `json:"weight_ranking"`
+	Deciders         []AllocationDecider `json:"deciders,omitempty"`
+}
+
+// AllocationDecider represents the outcome of a single allocation decider on a node
+type AllocationDecider struct {
+	Decider     string `json:"decider"`
+	Decision    string `json:"decision"`
+	Explanation string `json:"explanation"`
+}
+
+// IndexStats represents the response of the index stats API
+type IndexStats struct {
+	Shards struct {
+		Total      int `json:"total"`
+		Successful int `json:"successful"`
+		Failed     int `json:"failed"`
+	} `json:"_shards"`
+	All     IndexStatsGroup            `json:"_all"`
+	Indices map[string]IndexStatsGroup `json:"indices"`
+
+	// Raw holds the full response for fields not covered above
+	Raw map[string]any `json:"-"`
+}
+
+// IndexStatsGroup holds the primaries and total statistics for one index (or all indices)
+type IndexStatsGroup struct {
+	UUID      string            `json:"uuid,omitempty"`
+	Health    string            `json:"health,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Primaries IndexStatsSection `json:"primaries"`
+	Total     IndexStatsSection `json:"total"`
+}
+
+// IndexStatsSection holds the statistics of a set of shards
+type IndexStatsSection struct {
+	Docs     DocsStats     `json:"docs"`
+	Store    StoreStats    `json:"store"`
+	Indexing IndexingStats `json:"indexing"`
+	Search   SearchStats   `json:"search"`
+	Refresh  RefreshStats  `json:"refresh"`
+	Flush    FlushStats    `json:"flush"`
+	Merges   MergeStats    `json:"merges"`
+}
+
+// DocsStats represents document counts
+type DocsStats struct {
+	Count   int64 `json:"count"`
+	Deleted int64 `json:"deleted"`
+}
+
+// StoreStats represents store size statistics
+type StoreStats struct {
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+// IndexingStats represents indexing statistics
+type IndexingStats struct {
+	IndexTotal         int64 `json:"index_total"`
+	IndexTimeInMillis  int64 `json:"index_time_in_millis"`
+	IndexCurrent       int64 `json:"index_current"`
+	IndexFailed        int64 `json:"index_failed"`
+	DeleteTotal        int64 `json:"delete_total"`
+	DeleteTimeInMillis int64 `json:"delete_time_in_millis"`
+}
+
+// SearchStats represents search statistics
+type SearchStats struct {
+	OpenContexts      int64 `json:"open_contexts"`
+	QueryTotal        int64 `json:"query_total"`
+	QueryTimeInMillis int64 `json:"query_time_in_millis"`
+	QueryCurrent      int64 `json:"query_current"`
+	FetchTotal        int64 `json:"fetch_total"`
+	FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+	ScrollTotal       int64 `json:"scroll_total"`
+	ScrollCurrent     int64 `json:"scroll_current"`
+}
+
+// RefreshStats represents refresh statistics
+type RefreshStats struct {
+	Total             int64 `json:"total"`
+	TotalTimeInMillis int64 `json:"total_time_in_millis"`
+}
+
+// FlushStats represents flush statistics
+type FlushStats struct {
+	Total             int64 `json:"total"`
+	TotalTimeInMillis int64 `json:"total_time_in_millis"`
+}
+
+// MergeStats represents merge statistics
+type MergeStats struct {
+	Current           int64 `json:"current"`
+	Total             int64 `json:"total"`
+	TotalTimeInMillis int64 `json:"total_time_in_millis"`
+}
+
+// IndexingRate returns the number of documents indexed per second since a previous snapshot
+func (s IndexStatsSection) IndexingRate(previous IndexStatsSection, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Indexing.IndexTotal-previous.Indexing.IndexTotal) / elapsed.Seconds()
+}
+
+// SearchRate returns the number of search queries per second since a previous snapshot
+func (s IndexStatsSection) SearchRate(previous IndexStatsSection, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Search.QueryTotal-previous.Search.QueryTotal) / elapsed.Seconds()
+}
+
+// SimulatePipelineResult represents the response of a simulated ingest pipeline run
+type SimulatePipelineResult struct {
+	Docs []SimulatedDocument `json:"docs"`
+}
+
+// SimulatedDocument represents the outcome of running one document through a simulated pipeline.
+// Doc and Error are set in the default mode, ProcessorResults in verbose mode.
+type SimulatedDocument struct {
+	Doc              *SimulatedDocumentSource   `json:"doc,omitempty"`
+	Error            *ErrorCause                `json:"error,omitempty"`
+	ProcessorResults []SimulatedProcessorResult `json:"processor_results,omitempty"`
+}
+
+// Source returns the document as the pipeline left it; in verbose mode that is the output of the
+// last processor that produced a document
+func (d SimulatedDocument) Source() map[string]any {
+	if d.Doc != nil {
+		return d.Doc.Source
+	}
+	for i := len(d.ProcessorResults) - 1; i >= 0; i-- {
+		if d.ProcessorResults[i].Doc != nil {
+			return d.ProcessorResults[i].Doc.Source
+		}
+	}
+	return nil
+}
+
+// Failed reports whether the pipeline failed for the document
+func (d SimulatedDocument) Failed() bool {
+	if d.Error != nil {
+		return true
+	}
+	for _, result := range d.ProcessorResults {
+		if result.Status == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// SimulatedDocumentSource represents a document produced by a simulated pipeline
+type SimulatedDocumentSource struct {
+	Index  string         `json:"_index"`
+	ID     string         `json:"_id"`
+	Source map[string]any `json:"_source"`
+	Ingest map[string]any `json:"_ingest,omitempty"`
+}
+
+// SimulatedProcessorResult represents the output of a single processor in a verbose simulation
+type SimulatedProcessorResult struct {
+	ProcessorType string                   `json:"processor_type"`
+	Status        string                   `json:"status"` // "success", "error", "error_ignored", "skipped" or "dropped"
+	Tag           string                   `json:"tag,omitempty"`
+	Description   string                   `json:"description,omitempty"`
+	Doc           *SimulatedDocumentSource `json:"doc,omitempty"`
+	Error         *ErrorCause              `json:"error,omitempty"`
+	IgnoredError  *ErrorCause              `json:"ignored_error,omitempty"`
+}